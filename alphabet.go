@@ -0,0 +1,183 @@
+package jnumber
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidAlphabet is returned by NewAlphabet if a rune cannot be added to an alphabet.
+var ErrInvalidAlphabet = errors.New("invalid alphabet")
+
+// Alphabet is a set of runes with numeric values, which can be used instead of the
+// fixed set of ValueOf. Create one with NewAlphabet. An Alphabet is a NumeralSystem
+// and can be used with FindIn and Convert. Formatting always uses the regular kanji.
+type Alphabet struct {
+	table      []runeValue
+	multiplier uint32
+	shift      uint32
+}
+
+// NewAlphabet returns an Alphabet that contains all runes of ValueOf and the given
+// additional runes, e.g. 弌, 弍 and 弎 for 1, 2 and 3. The values must be values of
+// ValueOf, i.e. 0 to 9, 10, 100, 1000, 10^4, 10^8, 10^12 or 10^16, and the runes
+// must be encoded with 3 or 4 bytes in UTF-8 like all kanji. Returns ErrInvalidAlphabet
+// if a rune does not fulfill these conditions or conflicts with an existing numeral.
+func NewAlphabet(values map[rune]uint64) (*Alphabet, error) {
+	entries := make([]runeValue, 0, len(values)+len(runeValues))
+	for _, entry := range runeValues {
+		if entry.Rune != 0 {
+			entries = append(entries, entry)
+		}
+	}
+	for r, value := range values {
+		if utf8.RuneLen(r) < utf8KanjiBytes || !isValueOfValue(value) {
+			return nil, ErrInvalidAlphabet
+		}
+		if existing, ok := ValueOf(r); ok {
+			if existing != value {
+				return nil, ErrInvalidAlphabet
+			}
+			continue
+		}
+		if bigUnitIndex(r) >= 0 || strings.ContainsRune(negativePrefix+"河沙僧祇由他可思議量大数", r) {
+			return nil, ErrInvalidAlphabet
+		}
+		entries = append(entries, runeValue{r, value})
+	}
+	return newAlphabet(entries, runeValuesMultiplier), nil
+}
+
+// ValueOf returns the numeric value of a single rune of the alphabet, if it has one.
+// A nil Alphabet behaves like the package level ValueOf.
+func (a *Alphabet) ValueOf(r rune) (value uint64, ok bool) {
+	if a == nil {
+		return ValueOf(r)
+	}
+	val := a.table[(a.multiplier*uint32(r))>>a.shift]
+	return val.Value, val.Rune == r && r != 0
+}
+
+// ParseInt returns the integer represented by the given numerals like the package
+// level ParseInt, but accepts all runes of the alphabet.
+func (a *Alphabet) ParseInt(s string) (int64, error) {
+	i, err := parseInt(s, a)
+	if err != nil {
+		return 0, newParseError("Alphabet.ParseInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseUint returns the unsigned integer represented by the given numerals like the
+// package level ParseUint, but accepts all runes of the alphabet.
+func (a *Alphabet) ParseUint(s string) (uint64, error) {
+	u, err := parseUint(s, a)
+	if err != nil {
+		return 0, newParseError("Alphabet.ParseUint", s, err)
+	}
+	return u, nil
+}
+
+// ParseBigInt returns the integer represented by the given numerals like the package
+// level ParseBigInt, but accepts all runes of the alphabet.
+func (a *Alphabet) ParseBigInt(s string) (*big.Int, error) {
+	i, err := parseBigInt(s, a)
+	if err != nil {
+		return nil, newParseError("Alphabet.ParseBigInt", s, err)
+	}
+	return i, nil
+}
+
+// AppendUint appends the given unsigned integer as regular japanese numerals to dst.
+func (a *Alphabet) AppendUint(dst []byte, u uint64) []byte {
+	return AppendUint(dst, u)
+}
+
+// AppendBigInt appends the given big integer as regular japanese numerals to dst.
+// Returns ErrOverflow if |i| >= 10^72.
+func (a *Alphabet) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendBigInt(dst, i)
+}
+
+// Span returns the number of bytes at the start of s that consist of runes of the
+// alphabet or complete units >= 垓.
+func (a *Alphabet) Span(s string) int {
+	return japaneseSpan(s, a.ValueOf)
+}
+
+// Find returns an array of all potential numerals of the alphabet in the given string.
+func (a *Alphabet) Find(s string) []*SearchResult {
+	return FindIn(a, s)
+}
+
+// FindBigInt returns an array of all potential numerals of the alphabet in the given
+// string.
+func (a *Alphabet) FindBigInt(s string) []*SearchBigIntResult {
+	return FindBigIntIn(a, s)
+}
+
+// newAlphabet generates a collision free table for the given entries. The table size
+// starts with the smallest power of two that can hold all entries and is doubled if
+// no perfect hash function is found. The given multiplier is tried first, which
+// allows to reproduce a known table like runeValues.
+func newAlphabet(entries []runeValue, multiplier uint32) *Alphabet {
+	bits := uint32(1)
+	for 1<<bits < len(entries) {
+		bits++
+	}
+	seed := multiplier
+	for ; ; bits++ {
+		table := make([]runeValue, 1<<bits)
+		shift := 32 - bits
+		m := multiplier
+		for attempt := 0; attempt < maxPerfectHashAttempts; attempt++ {
+			if fillTable(table, entries, m, shift) {
+				return &Alphabet{table: table, multiplier: m, shift: shift}
+			}
+			seed = xorshift32(seed)
+			m = seed | 1
+		}
+	}
+}
+
+// maxPerfectHashAttempts is the number of multipliers tried for a table size.
+const maxPerfectHashAttempts = 1 << 16
+
+// fillTable inserts all entries into the table and returns false if two entries have
+// the same hash.
+func fillTable(table []runeValue, entries []runeValue, multiplier uint32, shift uint32) bool {
+	for i := range table {
+		table[i] = runeValue{}
+	}
+	for _, entry := range entries {
+		hash := (multiplier * uint32(entry.Rune)) >> shift
+		if table[hash].Rune != 0 {
+			return false
+		}
+		table[hash] = entry
+	}
+	return true
+}
+
+// xorshift32 returns the next number of a simple pseudo random sequence, which keeps
+// the generated tables deterministic.
+func xorshift32(x uint32) uint32 {
+	if x == 0 {
+		x = 1
+	}
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	return x
+}
+
+// isValueOfValue reports whether the given value is a value of ValueOf.
+func isValueOfValue(value uint64) bool {
+	for _, entry := range runeValues {
+		if entry.Rune != 0 && entry.Value == value {
+			return true
+		}
+	}
+	return false
+}
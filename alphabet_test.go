@@ -0,0 +1,120 @@
+package jnumber
+
+import (
+	"testing"
+)
+
+var stampAlphabet = map[rune]uint64{
+	'弌':          1,
+	'弍':          2,
+	'弎':          3,
+	'\uE000':     100,
+	'壱':          1,
+	'\U00020001': 7,
+}
+
+var alphabetTestCases = []testCase[int64]{
+	{"弌", 1},
+	{"弍", 2},
+	{"弎", 3},
+	{"弍十弎", 23},
+	{"弎\uE000弍十", 320},
+	{"弌万弍千", 12_000},
+	{negativePrefix + "弍", -2},
+	{"\U00020001十\U00020001", 77},
+}
+
+var alphabetErrorCases = []parseErrorTestCase{
+	{"弌弍", ErrInvalidSequence},
+	{"弍\uE000\uE000", ErrInvalidSequence},
+	{"弐\uE001", &UnexpectedRuneError{'\uE001', 0}},
+}
+
+func TestNewAlphabetReproducesRuneValues(t *testing.T) {
+	entries := make([]runeValue, 0, len(runeValues))
+	for _, entry := range runeValues {
+		if entry.Rune != 0 {
+			entries = append(entries, entry)
+		}
+	}
+	alphabet := newAlphabet(entries, runeValuesMultiplier)
+	expectEqual(t, len(runeValues), len(alphabet.table))
+	for i := range runeValues {
+		expectEqual(t, runeValues[i], alphabet.table[i])
+	}
+}
+
+func TestNewAlphabetError(t *testing.T) {
+	for _, values := range []map[rune]uint64{
+		{'弌': 11},
+		{'弌': 10_000_000},
+		{'一': 2},
+		{'垓': 1},
+		{'河': 1},
+		{'ナ': 1},
+		{'a': 1},
+		{'é': 1},
+	} {
+		_, err := NewAlphabet(values)
+		expectErrIs(t, ErrInvalidAlphabet, err)
+	}
+}
+
+func TestAlphabetValueOf(t *testing.T) {
+	alphabet, err := NewAlphabet(stampAlphabet)
+	expectErrNil(t, err)
+	for r, expected := range stampAlphabet {
+		actual, ok := alphabet.ValueOf(r)
+		expectEqual(t, true, ok)
+		expectEqual(t, expected, actual)
+	}
+	for _, r := range uint64Kanjis {
+		expected, _ := ValueOf(r)
+		actual, ok := alphabet.ValueOf(r)
+		expectEqual(t, true, ok)
+		expectEqual(t, expected, actual)
+	}
+	for _, r := range []rune{0, 'a', '弐' + 1, '\uE001'} {
+		_, ok := alphabet.ValueOf(r)
+		expectEqual(t, false, ok)
+	}
+}
+
+func TestAlphabetParseInt(t *testing.T) {
+	alphabet, err := NewAlphabet(stampAlphabet)
+	expectErrNil(t, err)
+	testParse(t, commonTestCases, alphabet.ParseInt)
+	testParse(t, alphabetTestCases, alphabet.ParseInt)
+	testParseError(t, commonErrorCases, alphabet.ParseInt)
+	testParseError(t, alphabetErrorCases, alphabet.ParseInt)
+}
+
+func TestAlphabetParseBigInt(t *testing.T) {
+	alphabet, err := NewAlphabet(stampAlphabet)
+	expectErrNil(t, err)
+	for _, tc := range alphabetTestCases {
+		actual, err := alphabet.ParseBigInt(tc.String)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Value, actual.Int64())
+	}
+	actual, err := alphabet.ParseBigInt("弍垓弎")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(2, 20, 3).String(), actual.String())
+	for _, tc := range alphabetErrorCases {
+		_, err := alphabet.ParseBigInt(tc.Text)
+		expectErrIs(t, tc.Expected, err)
+	}
+}
+
+func TestAlphabetFind(t *testing.T) {
+	alphabet, err := NewAlphabet(stampAlphabet)
+	expectErrNil(t, err)
+	results := alphabet.Find("印: 弍十弎枚と五")
+	expectEqual(t, 2, len(results))
+	expectEqual(t, "弍十弎", results[0].Str)
+	expectEqual(t, uint64(23), results[0].Value)
+	expectEqual(t, "五", results[1].Str)
+	bigResults := alphabet.FindBigInt("弌垓")
+	expectEqual(t, 1, len(bigResults))
+	expectEqual(t, newTestBigInt(1, 20, 0).String(), bigResults[0].Value.String())
+}
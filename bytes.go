@@ -0,0 +1,76 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"unsafe"
+)
+
+// bytesToString returns the bytes as a string without copying them. The string
+// must not be retained after the call, because the caller may modify b.
+func bytesToString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// newBytesParseError returns the error of a parser for b with a copy of b as input,
+// so that the error stays valid if the caller modifies b.
+func newBytesParseError(fn string, b []byte, err error) error {
+	return newParseError(fn, string(b), err)
+}
+
+// ParseIntBytes returns the integer represented by the given UTF-8 encoded japanese
+// numerals like ParseInt without converting b to a string.
+func ParseIntBytes(b []byte) (int64, error) {
+	i, err := parseInt(bytesToString(b), nil)
+	if err != nil {
+		return 0, newBytesParseError("ParseIntBytes", b, err)
+	}
+	return i, nil
+}
+
+// ParseUintBytes returns the unsigned integer represented by the given UTF-8 encoded
+// japanese numerals like ParseUint without converting b to a string.
+func ParseUintBytes(b []byte) (uint64, error) {
+	u, err := parseUint(bytesToString(b), nil)
+	if err != nil {
+		return 0, newBytesParseError("ParseUintBytes", b, err)
+	}
+	return u, nil
+}
+
+// ParseSerialUintBytes returns the unsigned integer represented by the given UTF-8
+// encoded serial japanese numerals like ParseSerialUint without converting b to a
+// string.
+func ParseSerialUintBytes(b []byte) (uint64, error) {
+	u, err := parseSerialUint(bytesToString(b))
+	if err != nil {
+		return 0, newBytesParseError("ParseSerialUintBytes", b, err)
+	}
+	return u, nil
+}
+
+// ParseBigIntBytes returns the integer represented by the given UTF-8 encoded
+// japanese numerals like ParseBigInt without converting b to a string.
+func ParseBigIntBytes(b []byte) (*big.Int, error) {
+	i, err := parseBigInt(bytesToString(b), nil)
+	if err != nil {
+		return nil, newBytesParseError("ParseBigIntBytes", b, err)
+	}
+	return i, nil
+}
+
+// FindBytes returns an array of all potential japanese numerals in the given UTF-8
+// encoded text like Find. Str of each result is a copy of the match.
+func FindBytes(b []byte) []*SearchResult {
+	results := make([]*SearchResult, 0)
+	FindFunc(bytesToString(b), func(result SearchResult) bool {
+		result.Str = strings.Clone(result.Str)
+		if result.Err != nil {
+			// the error refers to the memory of b as well
+			result.Value, result.Err = ParseUint(result.Str)
+		}
+		results = append(results, &result)
+		return true
+	})
+	return results
+}
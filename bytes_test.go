@@ -0,0 +1,98 @@
+package jnumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseIntBytes(t *testing.T) {
+	for _, tc := range commonTestCases {
+		t.Run(tc.String, func(t *testing.T) {
+			actual, err := ParseIntBytes([]byte(tc.String))
+			expectErrNil(t, err)
+			expectEqual(t, tc.Value, actual)
+		})
+	}
+}
+
+func TestParseUintBytes(t *testing.T) {
+	actual, err := ParseUintBytes([]byte("一千二百三十四"))
+	expectErrNil(t, err)
+	expectEqual(t, uint64(1234), actual)
+}
+
+func TestParseSerialUintBytes(t *testing.T) {
+	for _, tc := range serialTestCases {
+		if tc.Value < 0 {
+			continue
+		}
+		t.Run(tc.String, func(t *testing.T) {
+			actual, err := ParseSerialUintBytes([]byte(tc.String))
+			expectErrNil(t, err)
+			expectEqual(t, uint64(tc.Value), actual)
+		})
+	}
+}
+
+func TestParseBigIntBytes(t *testing.T) {
+	for _, tc := range parseBigIntCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := ParseBigIntBytes([]byte(tc.Text))
+			expectErrNil(t, err)
+			expectEqual(t, tc.Expected.String(), actual.String())
+		})
+	}
+}
+
+func TestParseBytesError(t *testing.T) {
+	b := []byte("十百")
+	_, err := ParseUintBytes(b)
+	expectErrIs(t, ErrInvalidSequence, err)
+	// the error must not refer to the memory of the caller
+	copy(b, "一二")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, actual: %T", err)
+	}
+	expectEqual(t, "ParseUintBytes", parseErr.Func)
+	expectEqual(t, "十百", parseErr.Input)
+	expectEqual(t, 3, parseErr.Offset)
+}
+
+func TestParseBytesAllocs(t *testing.T) {
+	b := []byte("九百二十二京三千三百七十二兆三百六十八億五千四百七十七万五千八百七")
+	allocs := testing.AllocsPerRun(10, func() {
+		ParseUintBytes(b)
+	})
+	expectEqual(t, 0.0, allocs)
+}
+
+func TestFindBytes(t *testing.T) {
+	for _, tc := range findCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual := FindBytes([]byte(tc.Text))
+			expected := Find(tc.Text)
+			expectEqual(t, len(expected), len(actual))
+			for i, actualMatch := range actual {
+				expectEqual(t, expected[i].Start, actualMatch.Start)
+				expectEqual(t, expected[i].End, actualMatch.End)
+				expectEqual(t, expected[i].Str, actualMatch.Str)
+				expectEqual(t, expected[i].Value, actualMatch.Value)
+			}
+		})
+	}
+}
+
+func BenchmarkParseUintBytes(b *testing.B) {
+	for _, tc := range commonTestCases {
+		if tc.Value < 0 {
+			continue
+		}
+		input := []byte(tc.String)
+		b.Run(tc.String, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParseUintBytes(input)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ChineseStyle defines the characters used to format Chinese numerals.
+type ChineseStyle int
+
+const (
+	// ChineseSimplified uses simplified characters: 一万零五百
+	ChineseSimplified ChineseStyle = iota
+	// ChineseTraditional uses traditional characters: 一萬零五百
+	ChineseTraditional
+	// ChineseSimplifiedFinancial uses simplified financial characters (大写): 壹万零伍佰
+	ChineseSimplifiedFinancial
+	// ChineseTraditionalFinancial uses traditional financial characters (大寫): 壹萬零伍佰
+	ChineseTraditionalFinancial
+)
+
+const (
+	chineseNegativePrefix            = "负"
+	chineseTraditionalNegativePrefix = "負"
+	// chineseMaxDigits is the number of digits of the biggest supported number.
+	// The biggest unit is 極 (10^48).
+	chineseMaxDigits = 52
+)
+
+// chineseNumerals contains the characters of a ChineseStyle.
+type chineseNumerals struct {
+	Negative  string
+	Digits    [10]string
+	Units     [4]string
+	BigUnits  [chineseMaxDigits/4 - 1]string
+	Financial bool
+}
+
+var chineseStyles = [...]chineseNumerals{
+	ChineseSimplified: {
+		Negative: chineseNegativePrefix,
+		Digits:   [...]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		Units:    [...]string{"", "十", "百", "千"},
+		BigUnits: [...]string{"万", "亿", "兆", "京", "垓", "秭", "穰", "沟", "涧", "正", "载", "极"},
+	},
+	ChineseTraditional: {
+		Negative: chineseTraditionalNegativePrefix,
+		Digits:   [...]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		Units:    [...]string{"", "十", "百", "千"},
+		BigUnits: [...]string{"萬", "億", "兆", "京", "垓", "秭", "穰", "溝", "澗", "正", "載", "極"},
+	},
+	ChineseSimplifiedFinancial: {
+		Negative:  chineseNegativePrefix,
+		Digits:    [...]string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"},
+		Units:     [...]string{"", "拾", "佰", "仟"},
+		BigUnits:  [...]string{"万", "亿", "兆", "京", "垓", "秭", "穰", "沟", "涧", "正", "载", "极"},
+		Financial: true,
+	},
+	ChineseTraditionalFinancial: {
+		Negative:  chineseTraditionalNegativePrefix,
+		Digits:    [...]string{"零", "壹", "貳", "參", "肆", "伍", "陸", "柒", "捌", "玖"},
+		Units:     [...]string{"", "拾", "佰", "仟"},
+		BigUnits:  [...]string{"萬", "億", "兆", "京", "垓", "秭", "穰", "溝", "澗", "正", "載", "極"},
+		Financial: true,
+	},
+}
+
+// chineseRules contains the rules for Chinese numerals: 零 marks skipped units,
+// a single digit at the end belongs to the next lower unit (一千五 for 1500) and
+// 亿 may be multiplied with 万 (一万亿 for 10^12).
+var chineseRules = numeralRules{
+	System:              Myriad,
+	ZeroPlaceholders:    true,
+	ImplicitNextUnit:    true,
+	LargeHundredMillion: true,
+}
+
+// ParseChineseInt returns the integer represented by the given Chinese numerals.
+// Accepts simplified, traditional and financial characters, 两 and 兩 for 2 in
+// front of units and 负 or 負 as a negative prefix.
+func ParseChineseInt(s string) (int64, error) {
+	abs, isNegative := trimChineseNegativePrefix(s)
+	parser := newBigIntParser(nil, &chineseRules, nil)
+	err := parseChinese(&parser, abs)
+	if err == nil {
+		var sum uint64
+		if sum, err = parser.uint64(); err == nil {
+			var i int64
+			if i, err = toInt64(sum, isNegative); err == nil {
+				return i, nil
+			}
+		}
+	}
+	return 0, newParseError("ParseChineseInt", s, shiftError(err, len(s)-len(abs)))
+}
+
+// ParseChineseUint returns the unsigned integer represented by the given Chinese
+// numerals. See ParseChineseInt for the accepted characters.
+func ParseChineseUint(s string) (uint64, error) {
+	parser := newBigIntParser(nil, &chineseRules, nil)
+	err := parseChinese(&parser, s)
+	if err == nil {
+		var u uint64
+		if u, err = parser.uint64(); err == nil {
+			return u, nil
+		}
+	}
+	return 0, newParseError("ParseChineseUint", s, err)
+}
+
+// ParseChineseBigInt returns the integer represented by the given Chinese numerals.
+// See ParseChineseInt for the accepted characters.
+func ParseChineseBigInt(s string) (*big.Int, error) {
+	abs, isNegative := trimChineseNegativePrefix(s)
+	parser := newBigIntParser(new(big.Int), &chineseRules, nil)
+	if err := parseChinese(&parser, abs); err != nil {
+		return nil, newParseError("ParseChineseBigInt", s, shiftError(err, len(s)-len(abs)))
+	}
+	result := parser.bigInt()
+	if isNegative {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+func trimChineseNegativePrefix(s string) (string, bool) {
+	if abs, ok := strings.CutPrefix(s, chineseNegativePrefix); ok {
+		return abs, true
+	}
+	if abs, ok := strings.CutPrefix(s, chineseTraditionalNegativePrefix); ok {
+		return abs, true
+	}
+	return s, false
+}
+
+// parseChinese parses the given Chinese numerals without a sign with p.
+func parseChinese(p *bigIntParser, s string) error {
+	if s == "" {
+		return ErrEmpty
+	}
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		value, k, ok := chineseValueOf(r)
+		var err error
+		switch {
+		case !ok:
+			return errorAt(i, checkUnexpectedRune(s[i:]))
+		case (r == '两' || r == '兩') && !isChineseHundredOrMore(s[i+size:]):
+			// 两 is only used in front of 百, 千 and units >= 万
+			err = ErrInvalidSequence
+		case k >= 0:
+			err = p.endSegmentWith(k)
+		case value > 0:
+			err = p.push(value)
+		case size < len(s):
+			// zero is only valid if it is the only rune or a placeholder
+			err = p.zero(i)
+		}
+		if err != nil {
+			return errorAt(i, err)
+		}
+		i += size
+	}
+	return p.end()
+}
+
+// isChineseHundredOrMore reports whether s starts with 百, 千 or a unit >= 万.
+func isChineseHundredOrMore(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	value, k, _ := chineseValueOf(r)
+	return k >= 0 || value == i百 || value == i千
+}
+
+// chineseValueOf returns the value of a single Chinese numeral < 万 or the index in
+// myriadUnits of a unit >= 万, which is -1 for the numerals < 万.
+func chineseValueOf(r rune) (value uint64, k int, ok bool) {
+	switch r {
+	case '零', '〇':
+		return 0, -1, true
+	case '一', '壹':
+		return 1, -1, true
+	case '二', '两', '兩', '贰', '貳':
+		return 2, -1, true
+	case '三', '叁', '參':
+		return 3, -1, true
+	case '四', '肆':
+		return 4, -1, true
+	case '五', '伍':
+		return 5, -1, true
+	case '六', '陆', '陸':
+		return 6, -1, true
+	case '七', '柒':
+		return 7, -1, true
+	case '八', '捌':
+		return 8, -1, true
+	case '九', '玖':
+		return 9, -1, true
+	case '十', '拾':
+		return i十, -1, true
+	case '百', '佰':
+		return i百, -1, true
+	case '千', '仟':
+		return i千, -1, true
+	case '万', '萬':
+		return 0, 0, true
+	case '亿', '億':
+		return 0, 1, true
+	case '兆':
+		return 0, 2, true
+	case '京':
+		return 0, 3, true
+	case '垓':
+		return 0, 4, true
+	case '秭', '𥝱':
+		return 0, 5, true
+	case '穰', '穣':
+		return 0, 6, true
+	case '沟', '溝':
+		return 0, 7, true
+	case '涧', '澗':
+		return 0, 8, true
+	case '正':
+		return 0, 9, true
+	case '载', '載':
+		return 0, 10, true
+	case '极', '極':
+		return 0, 11, true
+	default:
+		return 0, -1, false
+	}
+}
+
+// AppendChineseInt appends the given integer as Chinese numerals in the given style to dst.
+func AppendChineseInt(dst []byte, i int64, style ChineseStyle) []byte {
+	var u uint64
+	if i < 0 {
+		u = uint64(-i)
+		dst = append(dst, chineseStyles[style].Negative...)
+	} else {
+		u = uint64(i)
+	}
+	return AppendChineseUint(dst, u, style)
+}
+
+// AppendChineseUint appends the given unsigned integer as Chinese numerals in the
+// given style to dst.
+func AppendChineseUint(dst []byte, u uint64, style ChineseStyle) []byte {
+	var groups [5]uint64
+	return appendChinese(dst, splitUint64(&groups, u), &chineseStyles[style])
+}
+
+// AppendChineseBigInt appends the given big integer as Chinese numerals in the given
+// style to dst. Returns ErrOverflow if |i| >= 10^52.
+func AppendChineseBigInt(dst []byte, i *big.Int, style ChineseStyle) ([]byte, error) {
+	initBigIntsOnce.Do(initBigInts)
+	if i.CmpAbs(myriadUnits[chineseMaxDigits/4-1].Value) >= 0 {
+		return dst, ErrOverflow
+	}
+	if i.Sign() < 0 {
+		dst = append(dst, chineseStyles[style].Negative...)
+	}
+	var groups [maxBigIntGroups]uint64
+	return appendChinese(dst, splitWords(&groups, i.Bits()), &chineseStyles[style]), nil
+}
+
+// FormatChineseInt returns the given integer as a string of Chinese numerals in
+// the given style.
+func FormatChineseInt(i int64, style ChineseStyle) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendChineseInt(dst, i, style)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatChineseUint returns the given unsigned integer as a string of Chinese
+// numerals in the given style.
+func FormatChineseUint(u uint64, style ChineseStyle) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendChineseUint(dst, u, style)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatChineseBigInt returns the given big integer as a string of Chinese numerals
+// in the given style. Returns ErrOverflow if |i| >= 10^52.
+func FormatChineseBigInt(i *big.Int, style ChineseStyle) (string, error) {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst, err := AppendChineseBigInt(dst, i, style)
+	if err != nil {
+		return "", err
+	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst)), nil
+}
+
+// appendChinese appends the number with the given groups of four digits (see
+// appendGroups) as Chinese numerals to dst. Zeros between two non-zero digits are
+// written as a single 零, even across big units: 10500 is 一万零五百 and 100000005
+// is 一亿零五.
+func appendChinese(dst []byte, groups []uint64, numerals *chineseNumerals) []byte {
+	if len(groups) == 0 {
+		return append(dst, numerals.Digits[0]...)
+	}
+	started, pendingZero := false, false
+	for k := len(groups) - 1; k >= 0; k-- {
+		for position, divisor := 3, uint64(i千); divisor > 0; position, divisor = position-1, divisor/10 {
+			digit := groups[k] / divisor % 10
+			if digit == 0 {
+				pendingZero = started
+				continue
+			}
+			if pendingZero {
+				dst = append(dst, numerals.Digits[0]...)
+				pendingZero = false
+			}
+			// 10 to 19 at the start are written without 一, except for financial numerals
+			if digit != 1 || position != 1 || started || numerals.Financial {
+				dst = append(dst, numerals.Digits[digit]...)
+			}
+			dst = append(dst, numerals.Units[position]...)
+			started = true
+		}
+		if k > 0 && groups[k] > 0 {
+			dst = append(dst, numerals.BigUnits[k-1]...)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,179 @@
+package jnumber
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var chineseTestCases = []testCase[int64]{
+	{"零", 0},
+	{"一", 1},
+	{"十", 10},
+	{"十五", 15},
+	{"二十", 20},
+	{"一百", 100},
+	{"一百一十", 110},
+	{"一百零五", 105},
+	{"一千", 1_000},
+	{"一千零五", 1_005},
+	{"一千零五十", 1_050},
+	{"一千零一十", 1_010},
+	{"一万", 10_000},
+	{"一万零五百", 10_500},
+	{"十万", 100_000},
+	{"十万零五", 100_005},
+	{"一百万零五千", 1_005_000},
+	{"一百零五万", 1_050_000},
+	{"一千零一万", 10_010_000},
+	{"一亿", i億},
+	{"一亿零五", i億 + 5},
+	{"一亿零一万", i億 + i万},
+	{"十亿零一万", 10*i億 + i万},
+	{"一兆", i兆},
+	{"九百二十二京三千三百七十二兆零三百六十八亿五千四百七十七万五千八百零七", math.MaxInt64},
+	{chineseNegativePrefix + "九百二十二京三千三百七十二兆零三百六十八亿五千四百七十七万五千八百零八", math.MinInt64},
+}
+
+var chineseParseTestCases = []testCase[int64]{
+	{"两百", 200},
+	{"两千", 2_000},
+	{"两万", 20_000},
+	{"兩億", 2 * i億},
+	{"二十二", 22},
+	{"一十", 10},
+	{"一千〇五", 1_005},
+	{"一万亿", i兆},
+	{"一千五", 1_500},
+	{"三百五", 350},
+	{"两万五", 25_000},
+	{"一亿五", 150_000_000},
+	{"十五", 15},
+	{"壹万零伍佰", 10_500},
+	{"壹萬零伍佰", 10_500},
+	{"贰拾", 20},
+	{"貳拾參", 23},
+	{chineseTraditionalNegativePrefix + "一萬", -10_000},
+}
+
+var chineseErrorCases = []parseErrorTestCase{
+	{"", ErrEmpty},
+	{"两", ErrInvalidSequence},
+	{"两十", ErrInvalidSequence},
+	{"十两", ErrInvalidSequence},
+	{"零五", ErrInvalidSequence},
+	{"一千零", ErrInvalidSequence},
+	{"一千零零五", ErrInvalidSequence},
+	{"一千零五百", ErrInvalidSequence},
+	{"一万零五千", ErrInvalidSequence},
+	{"一百零一万零五千", ErrInvalidSequence},
+	{"十零五", ErrInvalidSequence},
+	{"一一", ErrInvalidSequence},
+	{"陆陸", ErrInvalidSequence},
+	{"十百", ErrInvalidSequence},
+	{"一万二万", ErrInvalidSequence},
+	{"万", ErrInvalidSequence},
+	{"一万万亿", ErrInvalidSequence},
+	{"一万亿一兆", ErrInvalidSequence},
+	{"a", ErrUnexpectedRune},
+	{"一a", ErrUnexpectedRune},
+	{"九千二百二十三京三千七百二十兆", ErrOverflow},
+}
+
+func TestParseChineseInt(t *testing.T) {
+	testParse(t, chineseTestCases, ParseChineseInt)
+	testParse(t, chineseParseTestCases, ParseChineseInt)
+}
+
+func TestParseChineseIntError(t *testing.T) {
+	testParseError(t, chineseErrorCases, ParseChineseInt)
+}
+
+func TestParseChineseUint(t *testing.T) {
+	testParse(t, []testCase[uint64]{
+		{"一千八百四十四京六千七百四十四兆零七百三十七亿零九百五十五万一千六百一十五", math.MaxUint64},
+	}, ParseChineseUint)
+	testParseError(t, []parseErrorTestCase{
+		{"一千八百四十四京六千七百四十四兆零七百三十七亿零九百五十五万一千六百一十六", ErrOverflow},
+		{chineseNegativePrefix + "一", ErrUnexpectedRune},
+	}, ParseChineseUint)
+}
+
+func TestParseChineseBigInt(t *testing.T) {
+	for _, tc := range []parseBigIntTestCase{
+		{"一垓", newTestBigInt(1, 20, 0)},
+		{"一極零一", newTestBigInt(1, 48, 1)},
+		{"二𥝱", newTestBigInt(2, 24, 0)},
+		{"九千九百九十九极", newTestBigInt(9999, 48, 0)},
+		{chineseNegativePrefix + "一垓零五", newTestBigInt(-1, 20, -5)},
+	} {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := ParseChineseBigInt(tc.Text)
+			expectErrNil(t, err)
+			if actual == nil || tc.Expected.Cmp(actual) != 0 {
+				t.Errorf("expected: %s, actual: %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatChineseInt(t *testing.T) {
+	format := func(i int64) string { return FormatChineseInt(i, ChineseSimplified) }
+	appendFn := func(dst []byte, i int64) []byte { return AppendChineseInt(dst, i, ChineseSimplified) }
+	testFormat(t, chineseTestCases, format)
+	testAppend(t, chineseTestCases, appendFn)
+}
+
+func TestFormatChineseStyles(t *testing.T) {
+	for _, tc := range []struct {
+		Style    ChineseStyle
+		Value    int64
+		Expected string
+	}{
+		{ChineseSimplified, 10_500, "一万零五百"},
+		{ChineseTraditional, 10_500, "一萬零五百"},
+		{ChineseSimplifiedFinancial, 10_500, "壹万零伍佰"},
+		{ChineseTraditionalFinancial, 10_500, "壹萬零伍佰"},
+		{ChineseTraditional, -2 * i億, "負二億"},
+		{ChineseSimplifiedFinancial, 12, "壹拾贰"},
+		{ChineseTraditionalFinancial, 36, "參拾陸"},
+	} {
+		t.Run(tc.Expected, func(t *testing.T) {
+			expectEqual(t, tc.Expected, FormatChineseInt(tc.Value, tc.Style))
+		})
+	}
+}
+
+func TestFormatChineseBigInt(t *testing.T) {
+	actual, err := FormatChineseBigInt(newTestBigInt(1, 48, 1), ChineseTraditional)
+	expectErrNil(t, err)
+	expectEqual(t, "一極零一", actual)
+	actual, err = FormatChineseBigInt(newTestBigInt(-1, 52, 0), ChineseTraditional)
+	expectErrIs(t, ErrOverflow, err)
+	expectEqual(t, "", actual)
+}
+
+func TestFormatParseChineseRandom(t *testing.T) {
+	var limit big.Int
+	limit.Exp(big.NewInt(10), big.NewInt(chineseMaxDigits), nil)
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 100_000; i++ {
+		var expected big.Int
+		expected.Rand(random, &limit)
+		// more zeros to test the zero placeholders
+		expected.Rsh(&expected, uint(random.Intn(170)))
+		style := ChineseStyle(i % len(chineseStyles))
+		str, err := FormatChineseBigInt(&expected, style)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		actual, err := ParseChineseBigInt(str)
+		if err != nil {
+			t.Fatalf("err: %v, str: %s", err, str)
+		}
+		if actual.Cmp(&expected) != 0 {
+			t.Fatalf("expected: %s, actual: %s, str: %s", &expected, actual, str)
+		}
+	}
+}
@@ -160,6 +160,7 @@ var commonErrorCases = []parseErrorTestCase{
 	{"一零", ErrInvalidSequence},
 	{"〇一", &UnexpectedRuneError{'一', 0}},
 	{"零一", &UnexpectedRuneError{'一', 0}},
+	{"〇垓", &UnexpectedRuneError{'垓', 0}},
 	{"二十一十", ErrInvalidSequence},
 	{"一十二十", ErrInvalidSequence},
 	{"一万二万", ErrInvalidSequence},
@@ -167,6 +168,9 @@ var commonErrorCases = []parseErrorTestCase{
 	{string(utf8.RuneError), ErrEncoding},
 	{string(utf8.RuneError) + "一", ErrEncoding},
 	{"一" + string(utf8.RuneError), ErrEncoding},
+	{"一\U00020000", &UnexpectedRuneError{'\U00020000', 0}},
+	{"\U00020000一", &UnexpectedRuneError{'\U00020000', 0}},
+	{"一\xf0\xa5\x9d", ErrEncoding},
 }
 
 var intOverflowTestCases = []parseErrorTestCase{
@@ -178,11 +182,14 @@ var uintOverflowTestCases = []parseErrorTestCase{
 	{"千八百四十四京六千七百四十四兆七百三十七億九百五十五万千六百十六", ErrOverflow},
 	{"二千八百四十四京六千七百四十四兆七百三十七億九百五十五万千六百十五", ErrOverflow},
 	{"一垓", ErrOverflow},
+	{"一𥝱", ErrOverflow},
 }
 
 var parseSerialErrorCases = []parseErrorTestCase{
 	{"", ErrEmpty},
 	{"百二十三", ErrInvalidSequence},
+	{"一𥝱", ErrInvalidSequence},
+	{"一\U00020000", ErrInvalidSequence},
 }
 
 func expectEqual[T comparable](t *testing.T, expected, actual T) {
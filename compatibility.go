@@ -0,0 +1,199 @@
+package jnumber
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+var fromCompatibilityReplacer = strings.NewReplacer(
+	// circled ideographs
+	"㊀", "一", "㊁", "二", "㊂", "三", "㊃", "四", "㊄", "五",
+	"㊅", "六", "㊆", "七", "㊇", "八", "㊈", "九", "㊉", "十",
+	// parenthesized ideographs
+	"㈠", "一", "㈡", "二", "㈢", "三", "㈣", "四", "㈤", "五",
+	"㈥", "六", "㈦", "七", "㈧", "八", "㈨", "九", "㈩", "十",
+	// squared ideographs
+	"🈩", "一", "🈔", "二", "🈪", "三",
+	// Hangzhou numerals
+	"〡", "一", "〢", "二", "〣", "三", "〤", "四", "〥", "五",
+	"〦", "六", "〧", "七", "〨", "八", "〩", "九",
+	"〸", "十", "〹", "二十", "〺", "三十",
+	// CJK compatibility ideographs
+	"\uF96B", "參", "\uF973", "拾", "\uF9B2", "零", "\uF9D1", "六", "\uF9D3", "陸",
+)
+
+// FromCompatibility replaces circled (㊀), parenthesized (㈠) and squared (🈩)
+// ideographs, Hangzhou numerals (〡 and 〸) and CJK compatibility ideographs with
+// regular kanji.
+func FromCompatibility() *strings.Replacer {
+	return fromCompatibilityReplacer
+}
+
+// CompatibilityValueOf returns the numeric value of a single kanji like ValueOf, but
+// also knows the runes replaced by FromCompatibility.
+func CompatibilityValueOf(r rune) (value uint64, ok bool) {
+	switch {
+	case '㊀' <= r && r <= '㊈':
+		return uint64(r-'㊀') + 1, true
+	case '㈠' <= r && r <= '㈨':
+		return uint64(r-'㈠') + 1, true
+	case '〡' <= r && r <= '〩':
+		return uint64(r-'〡') + 1, true
+	case r == '㊉' || r == '㈩' || r == '〸':
+		return i十, true
+	}
+	switch r {
+	case '🈩':
+		return i一, true
+	case '🈔':
+		return i二, true
+	case '🈪':
+		return i三, true
+	case '〹':
+		return 20, true
+	case '〺':
+		return 30, true
+	case '\uF96B':
+		return i參, true
+	case '\uF973':
+		return i拾, true
+	case '\uF9B2':
+		return i零, true
+	case '\uF9D1', '\uF9D3':
+		return i六, true
+	default:
+		return ValueOf(r)
+	}
+}
+
+// JapaneseCompatibility is the NumeralSystem of ParseUint and AppendUint, which
+// additionally accepts all runes of CompatibilityValueOf. Formatting uses the
+// regular kanji.
+var JapaneseCompatibility NumeralSystem = japaneseCompatibilitySystem{}
+
+type japaneseCompatibilitySystem struct{}
+
+func (japaneseCompatibilitySystem) ParseUint(s string) (uint64, error) {
+	u, err := parseUint(fromCompatibilityReplacer.Replace(s), nil)
+	if err != nil {
+		return 0, newParseError("JapaneseCompatibility.ParseUint", s, compatibilityError(s, err))
+	}
+	return u, nil
+}
+
+func (japaneseCompatibilitySystem) ParseBigInt(s string) (*big.Int, error) {
+	i, err := parseBigInt(fromCompatibilityReplacer.Replace(s), nil)
+	if err != nil {
+		return nil, newParseError("JapaneseCompatibility.ParseBigInt", s, compatibilityError(s, err))
+	}
+	return i, nil
+}
+
+// compatibilityError moves the position of an error in the result of
+// fromCompatibilityReplacer to the corresponding rune of the original string s.
+func compatibilityError(s string, err error) error {
+	return normalizedError(s, err, func(r rune) int {
+		if _, ok := CompatibilityValueOf(r); ok {
+			return len(fromCompatibilityReplacer.Replace(string(r)))
+		}
+		return utf8.RuneLen(r)
+	})
+}
+
+func (japaneseCompatibilitySystem) AppendUint(dst []byte, u uint64) []byte {
+	return AppendUint(dst, u)
+}
+
+func (japaneseCompatibilitySystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendBigInt(dst, i)
+}
+
+func (japaneseCompatibilitySystem) Span(s string) int {
+	return japaneseSpan(s, CompatibilityValueOf)
+}
+
+// suzhouDigits contains the vertical forms of the Suzhou numerals.
+var suzhouDigits = [...]string{"〇", "〡", "〢", "〣", "〤", "〥", "〦", "〧", "〨", "〩"}
+
+// suzhouHorizontalDigits contains the horizontal forms of 1, 2 and 3.
+var suzhouHorizontalDigits = [...]string{"", "一", "二", "三"}
+
+// AppendSuzhouUint appends the given unsigned integer as Suzhou numerals (蘇州碼子)
+// to dst. The digits are positional like serial numbers. Consecutive digits 1 to 3
+// alternate between the vertical and horizontal form to keep them readable, e.g.
+// 〡一〤 for 114.
+func AppendSuzhouUint(dst []byte, u uint64) []byte {
+	var buffer [20]byte
+	vertical := false
+	for _, c := range strconv.AppendUint(buffer[:0], u, 10) {
+		digit := c - '0'
+		if 1 <= digit && digit <= 3 {
+			vertical = !vertical
+			if !vertical {
+				dst = append(dst, suzhouHorizontalDigits[digit]...)
+				continue
+			}
+		} else {
+			vertical = false
+		}
+		dst = append(dst, suzhouDigits[digit]...)
+	}
+	return dst
+}
+
+// FormatSuzhouUint returns the given unsigned integer as a string of Suzhou numerals.
+// See AppendSuzhouUint for the format.
+func FormatSuzhouUint(u uint64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendSuzhouUint(dst, u)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// ParseSuzhouUint returns the unsigned integer represented by the given Suzhou
+// numerals. Accepts the vertical and horizontal forms of 1, 2 and 3 in any order.
+func ParseSuzhouUint(s string) (uint64, error) {
+	u, err := parseSuzhouUint(s)
+	if err != nil {
+		return 0, newParseError("ParseSuzhouUint", s, err)
+	}
+	return u, nil
+}
+
+func parseSuzhouUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, ErrEmpty
+	}
+	var buffer [20]byte
+	digits := buffer[:0]
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		var digit rune
+		switch {
+		case r == '〇':
+			digit = 0
+		case '〡' <= r && r <= '〩':
+			digit = r - '〡' + 1
+		case r == '一':
+			digit = 1
+		case r == '二':
+			digit = 2
+		case r == '三':
+			digit = 3
+		default:
+			return 0, errorAt(i, checkUnexpectedRune(s[i:]))
+		}
+		if len(digits) == len(buffer) {
+			return 0, errorAt(i, ErrOverflow)
+		}
+		digits = append(digits, byte('0'+digit))
+		i += size
+	}
+	u, err := strconv.ParseUint(unsafe.String(unsafe.SliceData(digits), len(digits)), 10, 64)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+	return u, nil
+}
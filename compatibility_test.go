@@ -0,0 +1,88 @@
+package jnumber
+
+import (
+	"math"
+	"testing"
+)
+
+var compatibilityTestCases = []testCase[uint64]{
+	{"㊀", 1},
+	{"㊉", 10},
+	{"㈢", 3},
+	{"㈩", 10},
+	{"🈔", 2},
+	{"〥", 5},
+	{"〸", 10},
+	{"〹", 20},
+	{"〺", 30},
+	{"零", 0},
+	{"陸", 6},
+	{"〹〥", 25},
+	{"㊂百㊁十㊀", 321},
+	{"〤万拾", 40_010},
+}
+
+var suzhouTestCases = []testCase[uint64]{
+	{"〇", 0},
+	{"〡", 1},
+	{"〤", 4},
+	{"〡〇", 10},
+	{"〡一", 11},
+	{"〡一〡", 111},
+	{"〡一〤", 114},
+	{"〢三〢", 232},
+	{"〥〦〧〨〩", 56789},
+	{"〤〡〤〢", 4142},
+	{"〡〨〤〤〦〧〤〤〇〧〣〧〇〩〥〥〡〦〡〥", math.MaxUint64},
+}
+
+func TestCompatibilityValueOf(t *testing.T) {
+	for _, tc := range compatibilityTestCases[:11] {
+		r := []rune(tc.String)[0]
+		actual, ok := CompatibilityValueOf(r)
+		expectEqual(t, true, ok)
+		expectEqual(t, tc.Value, actual)
+	}
+	for _, r := range uint64Kanjis {
+		expected, _ := ValueOf(r)
+		actual, ok := CompatibilityValueOf(r)
+		expectEqual(t, true, ok)
+		expectEqual(t, expected, actual)
+	}
+	_, ok := CompatibilityValueOf('a')
+	expectEqual(t, false, ok)
+}
+
+func TestFromCompatibility(t *testing.T) {
+	expectEqual(t, "三百二十一", FromCompatibility().Replace("㊂百㈡十〡"))
+	expectEqual(t, "二十五", FromCompatibility().Replace("〹〥"))
+}
+
+func TestJapaneseCompatibility(t *testing.T) {
+	testParse(t, compatibilityTestCases, JapaneseCompatibility.ParseUint)
+	actual, err := JapaneseCompatibility.ParseBigInt("㊁垓")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(2, 20, 0).String(), actual.String())
+	results := FindIn(JapaneseCompatibility, "第㊂章と〹〥頁")
+	expectEqual(t, 2, len(results))
+	expectEqual(t, "㊂", results[0].Str)
+	expectEqual(t, uint64(3), results[0].Value)
+	expectEqual(t, "〹〥", results[1].Str)
+	expectEqual(t, uint64(25), results[1].Value)
+}
+
+func TestFormatSuzhouUint(t *testing.T) {
+	testFormat(t, suzhouTestCases, FormatSuzhouUint)
+	testAppend(t, suzhouTestCases, AppendSuzhouUint)
+}
+
+func TestParseSuzhouUint(t *testing.T) {
+	testParse(t, suzhouTestCases, ParseSuzhouUint)
+	testParse(t, []testCase[uint64]{{"一〡", 11}, {"二二", 22}}, ParseSuzhouUint)
+	testParseError(t, []parseErrorTestCase{
+		{"", ErrEmpty},
+		{"十", &UnexpectedRuneError{'十', 0}},
+		{"〡〨〤〤〦〧〤〤〇〧〣〧〇〩〥〥〡〦〡〦", ErrOverflow},
+		{"〡〨〤〤〦〧〤〤〇〧〣〧〇〩〥〥〡〦〡〥〇", ErrOverflow},
+	}, ParseSuzhouUint)
+}
@@ -0,0 +1,223 @@
+package jnumber
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Explanation describes why a string is not a valid japanese numeral.
+type Explanation struct {
+	// Err is the error of ParseBigInt.
+	Err *ParseError
+	// Start and End are the byte offsets of the runes that cause the error.
+	Start, End int
+	// Reason is a human readable description of the error in English.
+	Reason string
+	// ReasonJapanese is a human readable description of the error in Japanese.
+	ReasonJapanese string
+}
+
+// Explain returns the reason why the given string is not a valid japanese numeral or
+// nil if ParseBigInt accepts the string. Use Suggest to find possible corrections.
+func Explain(s string) *Explanation {
+	_, err := ParseBigInt(s)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return nil
+	}
+	e := &Explanation{Err: parseErr, Start: parseErr.Offset, End: parseErr.Offset}
+	if parseErr.Offset < len(s) {
+		_, size := utf8.DecodeRuneInString(s[parseErr.Offset:])
+		e.End += size
+	}
+	var unexpected *UnexpectedRuneError
+	switch {
+	case errors.Is(err, ErrEmpty):
+		e.explain("the string is empty", "文字列が空です")
+	case errors.Is(err, ErrEncoding):
+		e.explain("the string is not valid UTF-8", "UTF-8として不正なバイト列です")
+	case errors.Is(err, ErrEOF):
+		e.Start = strings.LastIndexAny(s, "恒阿那不無")
+		e.explain("the string ends in the middle of a multi kanji unit", "複数の漢字からなる単位が途中で終わっています")
+	case errors.As(err, &unexpected) && unexpected.Expected != 0:
+		e.explain(fmt.Sprintf("expected 「%c」, found 「%c」", unexpected.Expected, unexpected.Actual),
+			fmt.Sprintf("「%c」ではなく「%c」が必要です", unexpected.Actual, unexpected.Expected))
+	case errors.As(err, &unexpected):
+		e.explain(fmt.Sprintf("「%c」 is not a japanese numeral", unexpected.Actual),
+			fmt.Sprintf("「%c」は漢数字ではありません", unexpected.Actual))
+	case errors.Is(err, ErrOverflow):
+		e.explain("the number is too big", "数が大きすぎます")
+	default:
+		e.explainInvalidSequence(s)
+	}
+	return e
+}
+
+func (e *Explanation) explain(reason, reasonJapanese string) {
+	e.Reason = reason
+	e.ReasonJapanese = reasonJapanese
+}
+
+// explainInvalidSequence finds the numerals that cause ErrInvalidSequence.
+func (e *Explanation) explainInvalidSequence(s string) {
+	numerals := scanNumerals(s)
+	current := -1
+	for i, n := range numerals {
+		if n.Start == e.Start {
+			current = i
+			e.End = n.End
+		}
+	}
+	if current < 0 {
+		e.explain("invalid sequence of digits", "数字の並びが不正です")
+		return
+	}
+	n := numerals[current]
+	var previous *numeral
+	if current > 0 {
+		previous = &numerals[current-1]
+	}
+	switch {
+	case n.Value.Sign() == 0:
+		e.explain(fmt.Sprintf("「%s」 can only be used on its own", n.Text(s)),
+			fmt.Sprintf("「%s」は単独でしか使えません", n.Text(s)))
+	case n.Value.Cmp(&b十) < 0 && previous != nil && previous.Value.Cmp(&b十) < 0:
+		e.Start = previous.Start
+		e.explain(fmt.Sprintf("there is no unit between the digits 「%s」 and 「%s」", previous.Text(s), n.Text(s)),
+			fmt.Sprintf("数字「%s」と「%s」の間に単位がありません", previous.Text(s), n.Text(s)))
+	case n.Value.Cmp(&b万) >= 0 && (previous == nil || previous.Value.Cmp(&b万) >= 0):
+		e.explain(fmt.Sprintf("the unit 「%s」 has no multiplier", n.Text(s)),
+			fmt.Sprintf("単位「%s」の前に数字がありません", n.Text(s)))
+	case n.Value.Cmp(&b十) >= 0:
+		// find the smaller or equal unit in front of the current one
+		for i := current - 1; i >= 0; i-- {
+			other := numerals[i]
+			if n.Value.Cmp(&b万) < 0 && other.Value.Cmp(&b万) >= 0 {
+				break
+			}
+			if other.Value.Cmp(&b十) >= 0 && other.Value.Cmp(n.Value) <= 0 {
+				e.Start = other.Start
+				e.explain(fmt.Sprintf("the unit 「%s」 follows the smaller or equal unit 「%s」", n.Text(s), other.Text(s)),
+					fmt.Sprintf("単位「%s」が同じか小さい単位「%s」の後にあります", n.Text(s), other.Text(s)))
+				return
+			}
+		}
+		e.explain("invalid sequence of digits", "数字の並びが不正です")
+	default:
+		e.explain("invalid sequence of digits", "数字の並びが不正です")
+	}
+}
+
+// numeral is a single numeral of a string, multi kanji units are a single numeral.
+type numeral struct {
+	Start, End int
+	Value      *big.Int
+}
+
+// Text returns the numeral in s.
+func (n numeral) Text(s string) string {
+	return s[n.Start:n.End]
+}
+
+// scanNumerals returns all numerals at the start of s after an optional マイナス up
+// to the first rune that is not a numeral. The offsets include the sign like the
+// offsets of ParseBigInt.
+func scanNumerals(s string) []numeral {
+	initBigIntsOnce.Do(initBigInts)
+	var numerals []numeral
+	for i := len(s) - len(strings.TrimPrefix(s, negativePrefix)); i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		var value *big.Int
+		end := i + size
+		if u, ok := ValueOf(r); ok {
+			value = new(big.Int).SetUint64(u)
+		} else if unitSize := bigUnitSize(s[i:]); unitSize > 0 {
+			value, end = myriadUnits[bigUnitIndex(r)].Value, i+unitSize
+		} else {
+			break
+		}
+		numerals = append(numerals, numeral{i, end, value})
+		i = end
+	}
+	return numerals
+}
+
+// Suggestion is a possible correction of an invalid japanese numeral.
+type Suggestion struct {
+	// Text is the corrected numeral in the format of FormatBigInt.
+	Text string
+	// Value is the value of the corrected numeral.
+	Value *big.Int
+	// Reason is a description of the correction in English.
+	Reason string
+	// ReasonJapanese is a description of the correction in Japanese.
+	ReasonJapanese string
+}
+
+// Suggest returns possible corrections for a string that is not a valid japanese
+// numeral, e.g. 千百 for 百千 or 十一 for 一一 as a serial number. The suggestions
+// are ranked by their likelihood, corrections close to the error come first. Returns
+// nil if ParseBigInt accepts the string.
+func Suggest(s string) []Suggestion {
+	explanation := Explain(s)
+	if explanation == nil {
+		return nil
+	}
+	type candidate struct {
+		Suggestion
+		distance int
+	}
+	var candidates []candidate
+	add := func(text string, position int, reason, reasonJapanese string) {
+		value, err := ParseBigInt(text)
+		if err != nil {
+			return
+		}
+		distance := position - explanation.Start
+		if distance < 0 {
+			distance = -distance
+		}
+		candidates = append(candidates, candidate{Suggestion{FormatBigIntUnbounded(value), value, reason, reasonJapanese}, distance})
+	}
+	// digits without units may be a serial number
+	if value, err := ParseSerialInt(s); err == nil {
+		candidates = append(candidates, candidate{Suggestion{
+			FormatInt(value), big.NewInt(value),
+			"read as serial number", "位取り記数法として読む",
+		}, -1})
+	}
+	numerals := scanNumerals(s)
+	for i, n := range numerals {
+		// swapping keeps all numerals and is preferred over removing one
+		text := n.Text(s)
+		if i+1 < len(numerals) {
+			next := numerals[i+1]
+			nextText := next.Text(s)
+			add(s[:n.Start]+nextText+text+s[next.End:], n.Start,
+				fmt.Sprintf("swap 「%s」 and 「%s」", text, nextText), fmt.Sprintf("「%s」と「%s」を入れ替え", text, nextText))
+		}
+		add(s[:n.Start]+s[n.End:], n.Start,
+			fmt.Sprintf("remove 「%s」", text), fmt.Sprintf("「%s」を削除", text))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	suggestions := make([]Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		duplicate := false
+		for _, suggestion := range suggestions {
+			if suggestion.Value.Cmp(c.Value) == 0 {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			suggestions = append(suggestions, c.Suggestion)
+		}
+	}
+	return suggestions
+}
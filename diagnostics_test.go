@@ -0,0 +1,76 @@
+package jnumber
+
+import (
+	"testing"
+)
+
+var explainTestCases = []struct {
+	Text       string
+	Start, End int
+	Reason     string
+	Japanese   string
+}{
+	{"", 0, 0, "the string is empty", "文字列が空です"},
+	{"一一千", 0, 6, "there is no unit between the digits 「一」 and 「一」", "数字「一」と「一」の間に単位がありません"},
+	{"マイナス一一千", 12, 18, "there is no unit between the digits 「一」 and 「一」", "数字「一」と「一」の間に単位がありません"},
+	{"百十百", 3, 9, "the unit 「百」 follows the smaller or equal unit 「十」", "単位「百」が同じか小さい単位「十」の後にあります"},
+	{"一万二万", 3, 12, "the unit 「万」 follows the smaller or equal unit 「万」", "単位「万」が同じか小さい単位「万」の後にあります"},
+	{"京", 0, 3, "the unit 「京」 has no multiplier", "単位「京」の前に数字がありません"},
+	{"一零", 3, 6, "「零」 can only be used on its own", "「零」は単独でしか使えません"},
+	{"二十a", 6, 7, "「a」 is not a japanese numeral", "「a」は漢数字ではありません"},
+	{"一恒河一", 9, 12, "expected 「沙」, found 「一」", "「一」ではなく「沙」が必要です"},
+	{"一無量大", 3, 12, "the string ends in the middle of a multi kanji unit", "複数の漢字からなる単位が途中で終わっています"},
+}
+
+func TestExplain(t *testing.T) {
+	for _, tc := range explainTestCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual := Explain(tc.Text)
+			if actual == nil {
+				t.Fatal("expected explanation")
+			}
+			expectEqual(t, tc.Start, actual.Start)
+			expectEqual(t, tc.End, actual.End)
+			expectEqual(t, tc.Reason, actual.Reason)
+			expectEqual(t, tc.Japanese, actual.ReasonJapanese)
+		})
+	}
+	if actual := Explain("千百"); actual != nil {
+		t.Errorf("unexpected explanation: %s", actual.Reason)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	for _, tc := range []struct {
+		Text     string
+		Expected []string
+	}{
+		{"一一", []string{"十一", "一"}},
+		{"マイナス一一", []string{"マイナス十一", "マイナス一"}},
+		{"二〇二三", []string{"二千二十三"}},
+		{"一一千", []string{"千", "千一"}},
+		{"百十百", []string{"百十"}},
+		{"百千", []string{"千百", "千", "百"}},
+		{"千百", nil},
+	} {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual := Suggest(tc.Text)
+			if len(actual) != len(tc.Expected) {
+				t.Fatalf("expected: %v, actual: %v", tc.Expected, actual)
+			}
+			for i, suggestion := range actual {
+				expectEqual(t, tc.Expected[i], suggestion.Text)
+				expectEqual(t, FormatBigInt(suggestion.Value), suggestion.Text)
+			}
+		})
+	}
+}
+
+func TestSuggestReason(t *testing.T) {
+	suggestions := Suggest("一一")
+	expectEqual(t, "read as serial number", suggestions[0].Reason)
+	expectEqual(t, "位取り記数法として読む", suggestions[0].ReasonJapanese)
+	suggestions = Suggest("百十百")
+	expectEqual(t, "remove 「百」", suggestions[0].Reason)
+	expectEqual(t, "「百」を削除", suggestions[0].ReasonJapanese)
+}
@@ -0,0 +1,160 @@
+package jnumber
+
+import (
+	"math/big"
+	"unicode/utf8"
+)
+
+// Find returns an array of all potential japanese numerals in the given string.
+// Use FindFunc, FindAll or AppendFind to avoid the allocation of each result.
+func Find(s string) []*SearchResult {
+	results := make([]*SearchResult, 0)
+	FindFunc(s, func(result SearchResult) bool {
+		results = append(results, &result)
+		return true
+	})
+	return results
+}
+
+// AppendFind appends all potential japanese numerals in the given string to dst
+// like Find. AppendFind does not allocate if dst has enough capacity and all
+// numerals are valid.
+func AppendFind(dst []SearchResult, s string) []SearchResult {
+	FindFunc(s, func(result SearchResult) bool {
+		dst = append(dst, result)
+		return true
+	})
+	return dst
+}
+
+// FindAll returns an iterator over all potential japanese numerals in the given
+// string like Find, which can be used with range-over-func since Go 1.23:
+//
+//	for result := range jnumber.FindAll(s) { … }
+func FindAll(s string) func(yield func(SearchResult) bool) {
+	return func(yield func(SearchResult) bool) {
+		FindFunc(s, yield)
+	}
+}
+
+// FindFunc calls fn with all potential japanese numerals in the given string like
+// Find until fn returns false. Each numeral is parsed while it is scanned, only
+// invalid numerals are parsed again with ParseUint to get the error.
+func FindFunc(s string, fn func(SearchResult) bool) {
+	for i := 0; i < len(s)-2; {
+		if !isIntNumeral(s, i) {
+			if s[i] < utf8.RuneSelf {
+				i++
+			} else {
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size
+			}
+			continue
+		}
+		value, end, err := scanUint(s[i:], nil)
+		end += i
+		scanned := end
+		// an invalid sequence or a leading zero ends the parsing, but not the numeral
+		for end < len(s)-2 && isIntNumeral(s, end) {
+			end += utf8KanjiBytes
+		}
+		result := SearchResult{Start: i, End: end, Str: s[i:end], Value: value}
+		if err != nil || end != scanned {
+			result.Value, result.Err = ParseUint(result.Str)
+		}
+		if !fn(result) {
+			return
+		}
+		i = end
+	}
+}
+
+// isIntNumeral reports whether s[i:] starts with a rune of ValueOf. All of them are
+// encoded with 3 bytes.
+func isIntNumeral(s string, i int) bool {
+	_, ok := ValueOf(decodeUtf8Kanji(i, s))
+	return ok
+}
+
+// FindBigInt returns an array of all potential japanese numerals in the given string.
+// Matches that ParseBigInt rejects, but ParseSerialBigInt accepts, are serial
+// numbers like 二〇二三 and have the value of ParseSerialBigInt. Use
+// FindBigIntFunc, FindBigIntAll or AppendFindBigInt to avoid the allocation of
+// each result.
+func FindBigInt(s string) []*SearchBigIntResult {
+	results := make([]*SearchBigIntResult, 0)
+	FindBigIntFunc(s, func(result SearchBigIntResult) bool {
+		results = append(results, &result)
+		return true
+	})
+	return results
+}
+
+// AppendFindBigInt appends all potential japanese numerals in the given string to
+// dst like FindBigInt.
+func AppendFindBigInt(dst []SearchBigIntResult, s string) []SearchBigIntResult {
+	FindBigIntFunc(s, func(result SearchBigIntResult) bool {
+		dst = append(dst, result)
+		return true
+	})
+	return dst
+}
+
+// FindBigIntAll returns an iterator over all potential japanese numerals in the
+// given string like FindBigInt, which can be used with range-over-func since Go
+// 1.23.
+func FindBigIntAll(s string) func(yield func(SearchBigIntResult) bool) {
+	return func(yield func(SearchBigIntResult) bool) {
+		FindBigIntFunc(s, yield)
+	}
+}
+
+// FindBigIntFunc calls fn with all potential japanese numerals in the given string
+// like FindBigInt until fn returns false. Each numeral is parsed while it is
+// scanned, only invalid numerals are parsed again with ParseSerialBigInt and
+// ParseBigInt.
+func FindBigIntFunc(s string, fn func(SearchBigIntResult) bool) {
+	initBigIntsOnce.Do(initBigInts)
+	for i := 0; i < len(s)-2; {
+		if bigIntNumeralSize(s, i) == 0 {
+			if s[i] < utf8.RuneSelf {
+				i++
+			} else {
+				_, size := utf8.DecodeRuneInString(s[i:])
+				i += size
+			}
+			continue
+		}
+		parser := newBigIntParser(new(big.Int), &japaneseRules, nil)
+		end, err := parser.scan(s[i:])
+		end += i
+		scanned := end
+		// an invalid sequence or a leading zero ends the parsing, but not the numeral
+		for size := bigIntNumeralSize(s, end); size > 0; size = bigIntNumeralSize(s, end) {
+			end += size
+		}
+		result := SearchBigIntResult{Start: i, End: end, Str: s[i:end]}
+		if err == nil && end == scanned {
+			result.Value = parser.bigInt()
+		} else if result.Value, err = parseSerialBigInt(result.Str); err != nil {
+			result.Value, result.Err = ParseBigInt(result.Str)
+		}
+		if !fn(result) {
+			return
+		}
+		i = end
+	}
+}
+
+// bigIntNumeralSize returns the number of bytes of the numeral of ParseBigInt at
+// s[i:] or 0. Multi kanji units like 恒河沙 are only numerals if they are complete.
+func bigIntNumeralSize(s string, i int) int {
+	if i >= len(s)-2 {
+		return 0
+	}
+	r := decodeUtf8Kanji(i, s)
+	if _, ok := ValueOf(r); ok {
+		return utf8KanjiBytes
+	}
+	return bigUnitSize(s[i:])
+}
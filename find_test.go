@@ -0,0 +1,199 @@
+package jnumber
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// regexpInt and regexpBigInt are the patterns of the old regexp based versions of
+// Find and FindBigInt.
+var (
+	regexpInt    = regexp.MustCompile("(?:[" + string(uint64Kanjis[:]) + "])+")
+	regexpBigInt = regexp.MustCompile("(?:[" + string(uint64Kanjis[:]) + commonBigIntRunes + "]|恒河沙|阿僧祇|那由他|不可思議|無量大数)+")
+)
+
+// randomFindText returns a text with numerals, parts of numerals and other runes.
+func randomFindText(random *rand.Rand) string {
+	parts := []string{"一", "二", "〇", "十", "百", "千", "万", "億", "京", "壱", "垓", "𥝱", "澗",
+		"恒河沙", "恒河", "無量大数", "無量", "不可思議", "a", " ", "円", "、", "\xe4\xb8", "\xff", "\xe0\x80\x80"}
+	var b strings.Builder
+	for n := random.Intn(12); n > 0; n-- {
+		b.WriteString(parts[random.Intn(len(parts))])
+	}
+	return b.String()
+}
+
+func TestFindRegexp(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for n := 0; n < 100_000; n++ {
+		s := randomFindText(random)
+		matches := regexpInt.FindAllStringIndex(s, -1)
+		actual := Find(s)
+		if len(actual) != len(matches) {
+			t.Fatalf("%q: expected %d results, actual: %d", s, len(matches), len(actual))
+		}
+		for i, match := range matches {
+			expectedValue, expectedErr := ParseUint(s[match[0]:match[1]])
+			result := actual[i]
+			if result.Start != match[0] || result.End != match[1] || result.Value != expectedValue ||
+				!errors.Is(result.Err, errors.Unwrap(expectedErr)) {
+				t.Fatalf("%q: expected: %v %d %v, actual: %+v", s, match, expectedValue, expectedErr, result)
+			}
+		}
+	}
+}
+
+func TestFindBigIntRegexp(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	for n := 0; n < 100_000; n++ {
+		s := randomFindText(random)
+		matches := regexpBigInt.FindAllStringIndex(s, -1)
+		actual := FindBigInt(s)
+		if len(actual) != len(matches) {
+			t.Fatalf("%q: expected %d results, actual: %d", s, len(matches), len(actual))
+		}
+		for i, match := range matches {
+			expectedValue, expectedErr := ParseBigInt(s[match[0]:match[1]])
+			if expectedErr != nil {
+				if value, err := ParseSerialBigInt(s[match[0]:match[1]]); err == nil {
+					expectedValue, expectedErr = value, nil
+				}
+			}
+			result := actual[i]
+			if result.Start != match[0] || result.End != match[1] || fmt.Sprint(result.Err) != fmt.Sprint(expectedErr) ||
+				expectedErr == nil && expectedValue.Cmp(result.Value) != 0 {
+				t.Fatalf("%q: expected: %v %s %v, actual: %+v", s, match, expectedValue, expectedErr, result)
+			}
+		}
+	}
+}
+
+func TestAppendFind(t *testing.T) {
+	dst := make([]SearchResult, 0, 8)
+	for _, tc := range findCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual := AppendFind(dst[:0], tc.Text)
+			expectEqual(t, len(tc.Expected), len(actual))
+			for i, expected := range Find(tc.Text) {
+				expectEqual(t, expected.Str, actual[i].Str)
+				expectEqual(t, expected.Value, actual[i].Value)
+				expectEqual(t, fmt.Sprint(expected.Err), fmt.Sprint(actual[i].Err))
+			}
+		})
+	}
+}
+
+func TestAppendFindAllocs(t *testing.T) {
+	dst := make([]SearchResult, 0, 8)
+	s := "二千二十三年に十人で三百五十万円の予算がある。"
+	allocs := testing.AllocsPerRun(10, func() {
+		dst = AppendFind(dst[:0], s)
+	})
+	expectEqual(t, 0.0, allocs)
+	expectEqual(t, 3, len(dst))
+	expectEqual(t, uint64(3_500_000), dst[2].Value)
+}
+
+func TestFindAll(t *testing.T) {
+	var values []uint64
+	FindAll("一、二、三")(func(result SearchResult) bool {
+		values = append(values, result.Value)
+		return result.Value < 2
+	})
+	expectEqual(t, 2, len(values))
+	expectEqual(t, uint64(2), values[1])
+}
+
+func TestFindInvalidUtf8(t *testing.T) {
+	expectEqual(t, 0, len(Find("x\xe0\x80\x80y")))
+	expectEqual(t, 0, len(FindBigInt("x\xe0\x80\x80y")))
+	results := Find("一\xe0\x80\x80二")
+	expectEqual(t, 2, len(results))
+	expectEqual(t, "一", results[0].Str)
+	expectEqual(t, "二", results[1].Str)
+}
+
+func TestFindBigIntFunc(t *testing.T) {
+	var results []SearchBigIntResult
+	FindBigIntFunc("一恒河沙と二無量、三𥝱", func(result SearchBigIntResult) bool {
+		results = append(results, result)
+		return true
+	})
+	expectEqual(t, 3, len(results))
+	expectEqual(t, "一恒河沙", results[0].Str)
+	expectEqual(t, "二", results[1].Str)
+	expectEqual(t, "三𥝱", results[2].Str)
+}
+
+func TestAppendFindBigInt(t *testing.T) {
+	dst := make([]SearchBigIntResult, 0, 8)
+	s := "一無量大数無量大数と二〇二三年、三百五十万円"
+	dst = AppendFindBigInt(dst[:0], s)
+	expectEqual(t, 3, len(dst))
+	for i, expected := range FindBigInt(s) {
+		expectEqual(t, expected.Str, dst[i].Str)
+		expectEqual(t, expected.Value.String(), dst[i].Value.String())
+		expectErrNil(t, dst[i].Err)
+	}
+	expectEqual(t, "2023", dst[1].Value.String())
+}
+
+func TestFindBigIntAll(t *testing.T) {
+	var values []string
+	FindBigIntAll("一垓、二垓、三垓")(func(result SearchBigIntResult) bool {
+		values = append(values, result.Value.String())
+		return len(values) < 2
+	})
+	expectEqual(t, 2, len(values))
+	expectEqual(t, "200000000000000000000", values[1])
+}
+
+func BenchmarkFind(b *testing.B) {
+	s := strings.Repeat("今年は二〇二三年ではなく二千二十三年で、三百五十万円の予算がある。", 10)
+	b.Run("Find", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Find(s)
+		}
+	})
+	b.Run("AppendFind", func(b *testing.B) {
+		dst := make([]SearchResult, 0, 64)
+		for i := 0; i < b.N; i++ {
+			dst = AppendFind(dst[:0], s)
+		}
+	})
+	b.Run("regexp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, match := range regexpInt.FindAllStringIndex(s, -1) {
+				ParseUint(s[match[0]:match[1]])
+			}
+		}
+	})
+}
+
+func BenchmarkFindBigInt(b *testing.B) {
+	s := strings.Repeat("今年は二〇二三年ではなく二千二十三年で、一垓五千万円の予算がある。", 10)
+	b.Run("FindBigInt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FindBigInt(s)
+		}
+	})
+	b.Run("AppendFindBigInt", func(b *testing.B) {
+		dst := make([]SearchBigIntResult, 0, 64)
+		for i := 0; i < b.N; i++ {
+			dst = AppendFindBigInt(dst[:0], s)
+		}
+	})
+	b.Run("regexp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, match := range regexpBigInt.FindAllStringIndex(s, -1) {
+				if _, err := ParseBigInt(s[match[0]:match[1]]); err != nil {
+					ParseSerialBigInt(s[match[0]:match[1]])
+				}
+			}
+		}
+	})
+}
@@ -2,6 +2,8 @@ package jnumber
 
 import (
 	"math/big"
+	"math/bits"
+	"sync"
 	"unsafe"
 )
 
@@ -60,7 +62,8 @@ func AppendSerialUint(dst []byte, u uint64) []byte {
 	return dst
 }
 
-// FormatInt returns the given integer as a string of Japanese numerals.
+// FormatInt returns the given integer as a string of Japanese numerals. Negative
+// numbers start with マイナス, use Signs.FormatInt for other signs.
 func FormatInt(i int64) string {
 	if 0 <= i && i < numberOfFastSmalls {
 		return formatSmall(int(i))
@@ -116,120 +119,295 @@ func FormatSerialUint(u uint64) string {
 	return unsafe.String(unsafe.SliceData(dst), len(dst))
 }
 
-func formatUnsigned(dst []byte, u uint64) []byte {
-	if u >= i京 {
-		dst, u = formatAppend(dst, u, "京", i京, u/i京)
-	}
-	if u >= i兆 {
-		dst, u = formatAppend(dst, u, "兆", i兆, u/i兆)
+// AppendSerialIntWidth appends the given integer as serial japanese numerals to dst
+// and pads the digits with leading 〇 to at least width digits, e.g. 〇〇四二 for 42
+// and width 4. The width does not include the negative prefix.
+func AppendSerialIntWidth(dst []byte, i int64, width int) []byte {
+	var u uint64
+	if i < 0 {
+		u = uint64(-i)
+		dst = append(dst, negativePrefix...)
+	} else {
+		u = uint64(i)
 	}
-	if u >= i億 {
-		dst, u = formatAppend(dst, u, "億", i億, u/i億)
+	return AppendSerialUintWidth(dst, u, width)
+}
+
+// AppendSerialUintWidth appends the given unsigned integer as serial japanese
+// numerals to dst and pads the digits with leading 〇 to at least width digits.
+func AppendSerialUintWidth(dst []byte, u uint64, width int) []byte {
+	digits := 1
+	for v := u; v >= 10; v /= 10 {
+		digits++
 	}
-	if u >= i万 {
-		dst, u = formatAppend(dst, u, "万", i万, u/i万)
+	for ; digits < width; digits++ {
+		dst = append(dst, serialInts[0]...)
 	}
-	if u >= i千 {
-		dst, u = formatAppend(dst, u, "千", i千, u/i千)
+	return AppendSerialUint(dst, u)
+}
+
+// FormatSerialIntWidth returns the given integer as a string of serial japanese
+// numerals with at least width digits. See AppendSerialIntWidth.
+func FormatSerialIntWidth(i int64, width int) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendSerialIntWidth(dst, i, width)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatSerialUintWidth returns the given unsigned integer as a string of serial
+// japanese numerals with at least width digits. See AppendSerialUintWidth.
+func FormatSerialUintWidth(u uint64, width int) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendSerialUintWidth(dst, u, width)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// AppendSerialBigInt appends the given big integer as japanese numerals to dst,
+// where the decimal digits 0 to 9 are replaced by the kanjis 〇 to 九.
+func AppendSerialBigInt(dst []byte, i *big.Int) []byte {
+	if i.IsInt64() {
+		return AppendSerialInt(dst, i.Int64())
 	}
-	if u > i百 {
-		dst, u = formatAppend(dst, u, "百", i百, u/i百)
+	var buffer [64]byte
+	digits := i.Append(buffer[:0], 10)
+	if digits[0] == '-' {
+		dst = append(dst, negativePrefix...)
+		digits = digits[1:]
 	}
-	if 0 < u {
-		dst = append(dst, formatSmall(int(u))...)
+	for _, digit := range digits {
+		dst = append(dst, serialInts[digit-'0']...)
 	}
 	return dst
 }
 
-func formatAppend(dst []byte, u uint64, kanji string, kanjiValue uint64, multiplier uint64) ([]byte, uint64) {
-	totalValue := multiplier * kanjiValue
-	if multiplier == 1 {
-		if kanjiValue >= i万 {
-			dst = append(dst, "一"...)
-		}
-	} else if multiplier < numberOfFastSmalls {
-		dst = append(dst, formatSmall(int(multiplier))...)
-	} else {
-		if multiplier >= i千 {
-			dst, multiplier = formatAppend(dst, multiplier, "千", i千, multiplier/i千)
+// FormatSerialBigInt returns the given big integer as a string of japanese numerals,
+// where the decimal digits 0 to 9 are replaced by the kanjis 〇 to 九.
+func FormatSerialBigInt(i *big.Int) string {
+	if i.IsInt64() {
+		return FormatSerialInt(i.Int64())
+	}
+	dst := make([]byte, 0, 2*initialFormatBufferSize)
+	dst = AppendSerialBigInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// formatUnsigned appends u > 0 to dst. Zero appends nothing.
+func formatUnsigned(dst []byte, u uint64) []byte {
+	var groups [5]uint64
+	return appendGroups(dst, splitUint64(&groups, u))
+}
+
+// splitUint64 returns the groups of four digits of u for appendGroups, the least
+// significant group first. 2^64 < 10^20 has at most 5 groups.
+func splitUint64(groups *[5]uint64, u uint64) []uint64 {
+	count := 0
+	for ; u > 0; count++ {
+		groups[count] = u % i万
+		u /= i万
+	}
+	return groups[:count]
+}
+
+// groupTable contains the numerals of all groups of four digits 1 to 9999 one after
+// another, e.g. 千二百三十四 for 1234. Group g is groupTable[groupOffsets[g]:groupOffsets[g+1]].
+// Built on first use by initGroupTable.
+var (
+	groupTable     string
+	groupOffsets   *[i万 + 1]uint32
+	groupTableOnce sync.Once
+)
+
+func initGroupTable() {
+	table, offsets := initGroups(serialInts[:], []string{"", "十", "百", "千"})
+	groupTable, groupOffsets = table, &offsets
+}
+
+// initGroups returns the table of all groups for the given digits and units 十, 百
+// and 千, where units[e] is the unit of 10^e.
+func initGroups(digits []string, units []string) (string, [i万 + 1]uint32) {
+	var offsets [i万 + 1]uint32
+	table := make([]byte, 0, 250_000)
+	for g := 1; g < i万; g++ {
+		offsets[g] = uint32(len(table))
+		for exponent, divisor := 3, i千; exponent > 0; exponent, divisor = exponent-1, divisor/10 {
+			// the formatter omits 一 in front of 十, 百 and 千
+			if digit := g / divisor % 10; digit > 1 {
+				table = append(table, digits[digit]...)
+				table = append(table, units[exponent]...)
+			} else if digit == 1 {
+				table = append(table, units[exponent]...)
+			}
 		}
-		if multiplier > i百 {
-			dst, multiplier = formatAppend(dst, multiplier, "百", i百, multiplier/i百)
+		if digit := g % 10; digit > 0 {
+			table = append(table, digits[digit]...)
 		}
-		if 0 < multiplier {
-			dst = append(dst, formatSmall(int(multiplier))...)
+	}
+	offsets[i万] = uint32(len(table))
+	offsets[0] = offsets[1]
+	return string(table), offsets
+}
+
+// appendGroups appends the number with the given groups of four digits to dst,
+// where groups[k] is the multiplier of myriadUnits[k-1] and groups[0] the part < 万.
+// Zero groups are omitted, so a number without groups appends nothing.
+func appendGroups(dst []byte, groups []uint64) []byte {
+	groupTableOnce.Do(initGroupTable)
+	for k := len(groups) - 1; k >= 0; k-- {
+		if g := groups[k]; g > 0 {
+			dst = append(dst, groupTable[groupOffsets[g]:groupOffsets[g+1]]...)
+			if k > 0 {
+				dst = append(dst, myriadUnits[k-1].Kanji...)
+			}
 		}
 	}
-	dst = append(dst, kanji...)
-	return dst, u - totalValue
+	return dst
 }
 
-// FormatBigInt returns the given big integer as a string of Japanese numerals.
-// Supports only numbers |i| < 10^72.
-func FormatBigInt(i *big.Int) string {
+// AppendBigInt appends the given big integer as Japanese numerals to dst. Returns
+// ErrOverflow if |i| >= 10^72. Use AppendBigIntUnbounded for bigger numbers.
+// AppendBigInt does not allocate if dst has enough capacity.
+func AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
 	if i.IsInt64() {
-		return FormatInt(i.Int64())
+		return AppendInt(dst, i.Int64()), nil
 	} else if i.IsUint64() {
-		return FormatUint(i.Uint64())
+		return AppendUint(dst, i.Uint64()), nil
+	}
+	initBigIntsOnce.Do(initBigInts)
+	if i.CmpAbs(&maxBigIntLimit) >= 0 {
+		return dst, ErrOverflow
+	}
+	if i.Sign() < 0 {
+		dst = append(dst, negativePrefix...)
+	}
+	return formatBigInt(dst, i.Bits()), nil
+}
+
+// AppendBigIntUnbounded appends the given big integer as Japanese numerals to dst.
+// Numbers |i| >= 10^72 repeat 無量大数 for their multiplier, e.g. 一万無量大数 for
+// 10^72 or 一無量大数無量大数 for 10^136. ParseBigInt accepts this notation.
+func AppendBigIntUnbounded(dst []byte, i *big.Int) []byte {
+	initBigIntsOnce.Do(initBigInts)
+	if i.CmpAbs(&maxBigIntLimit) < 0 {
+		dst, _ = AppendBigInt(dst, i)
+		return dst
 	}
 	var u big.Int
 	u.Abs(i)
-	dst := make([]byte, 0, initialFormatBufferSize)
 	if i.Sign() < 0 {
 		dst = append(dst, negativePrefix...)
 	}
-	initBigIntsOnce.Do(initBigInts)
-	dst = formatBigInt(dst, &u)
-	return unsafe.String(unsafe.SliceData(dst), len(dst))
+	// the digits of base 10^68 are separated by 無量大数
+	digits := splitBigInt(nil, &u, bigIntPowers(&u))
+	top := len(digits) - 1
+	for digits[top].Sign() == 0 {
+		top--
+	}
+	dst = formatBigInt(dst, digits[top].Bits())
+	for j := top - 1; j >= 0; j-- {
+		dst = append(dst, myriadUnits[len(myriadUnits)-1].Kanji...)
+		dst = formatBigInt(dst, digits[j].Bits())
+	}
+	return dst
 }
 
-func formatBigInt(dst []byte, u *big.Int) []byte {
-	dst = formatAppendBigInt(dst, u, "無量大数", &b無量大数)
-	dst = formatAppendBigInt(dst, u, "不可思議", &b不可思議)
-	dst = formatAppendBigInt(dst, u, "那由他", &b那由他)
-	dst = formatAppendBigInt(dst, u, "阿僧祇", &b阿僧祇)
-	dst = formatAppendBigInt(dst, u, "恒河沙", &b恒河沙)
-	dst = formatAppendBigInt(dst, u, "極", &b極)
-	dst = formatAppendBigInt(dst, u, "載", &b載)
-	dst = formatAppendBigInt(dst, u, "正", &b正)
-	dst = formatAppendBigInt(dst, u, "澗", &b澗)
-	dst = formatAppendBigInt(dst, u, "溝", &b溝)
-	dst = formatAppendBigInt(dst, u, "穣", &b穣)
-	dst = formatAppendBigInt(dst, u, "秭", &b秭)
-	dst = formatAppendBigInt(dst, u, "垓", &b垓)
-	dst = formatAppendBigInt(dst, u, "京", &b京)
-	return formatUnsigned(dst, u.Uint64())
-}
-
-func formatAppendBigInt(dst []byte, u *big.Int, kanji string, kanjiValue *big.Int) []byte {
-	if u.Cmp(kanjiValue) < 0 {
-		return dst
+// bigIntPowers returns the powers (10^68)^(2^k) <= u for k = 0, 1, 2 …
+func bigIntPowers(u *big.Int) []*big.Int {
+	powers := []*big.Int{&b無量大数}
+	for {
+		power := new(big.Int).Mul(powers[len(powers)-1], powers[len(powers)-1])
+		if power.Cmp(u) > 0 {
+			return powers
+		}
+		powers = append(powers, power)
+	}
+}
+
+// splitBigInt appends the 2^len(powers) digits of u < (10^68)^(2^len(powers)) in
+// base 10^68 to digits, the least significant digit first. u is divided by the
+// biggest power and both halves are split recursively, which needs fewer and more
+// balanced divisions than repeated divisions by 10^68.
+func splitBigInt(digits []*big.Int, u *big.Int, powers []*big.Int) []*big.Int {
+	if len(powers) == 0 {
+		return append(digits, u)
 	}
-	var multiplier big.Int
-	multiplier.Div(u, kanjiValue)
-	var totalValue big.Int
-	totalValue.Mul(&multiplier, kanjiValue)
+	k := len(powers) - 1
+	quotient, remainder := new(big.Int).QuoRem(u, powers[k], new(big.Int))
+	digits = splitBigInt(digits, remainder, powers[:k])
+	return splitBigInt(digits, quotient, powers[:k])
+}
+
+// FormatBigInt returns the given big integer as a string of Japanese numerals.
+// Supports only numbers |i| < 10^72 and returns an empty string for bigger numbers.
+// Use FormatBigIntErr or FormatBigIntUnbounded if the input may be bigger.
+func FormatBigInt(i *big.Int) string {
+	s, _ := FormatBigIntErr(i)
+	return s
+}
 
-	if multiplier.Cmp(&maxBigIntMultiplier) > 0 {
-		multiplier.Set(&maxBigIntMultiplier)
+// FormatBigIntErr returns the given big integer as a string of Japanese numerals.
+// Returns ErrOverflow if |i| >= 10^72.
+func FormatBigIntErr(i *big.Int) (string, error) {
+	if i.IsInt64() {
+		return FormatInt(i.Int64()), nil
+	}
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst, err := AppendBigInt(dst, i)
+	if err != nil {
+		return "", err
 	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst)), nil
+}
 
-	if intMultiplier := multiplier.Uint64(); intMultiplier < numberOfFastSmalls {
-		dst = append(dst, formatSmall(int(intMultiplier))...)
-	} else {
-		if intMultiplier >= i千 {
-			dst, intMultiplier = formatAppend(dst, intMultiplier, "千", i千, intMultiplier/i千)
+// FormatBigIntUnbounded returns the given big integer as a string of Japanese
+// numerals. See AppendBigIntUnbounded for the notation of numbers |i| >= 10^72.
+func FormatBigIntUnbounded(i *big.Int) string {
+	if i.IsInt64() {
+		return FormatInt(i.Int64())
+	}
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendBigIntUnbounded(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// maxBigIntGroups is the number of groups of four digits of numbers < 10^72.
+const maxBigIntGroups = 18
+
+// formatBigInt appends the natural number with the given words (see big.Int.Bits)
+// to dst. The number must be < 10^72.
+func formatBigInt(dst []byte, words []big.Word) []byte {
+	if u, ok := uint128FromWords(words); ok {
+		if u.Hi == 0 {
+			return formatUnsigned(dst, u.Lo)
 		}
-		if intMultiplier > i百 {
-			dst, intMultiplier = formatAppend(dst, intMultiplier, "百", i百, intMultiplier/i百)
+		return formatUint128(dst, u)
+	}
+	var groups [maxBigIntGroups]uint64
+	return appendGroups(dst, splitWords(&groups, words))
+}
+
+// splitWords returns the groups of four digits of the natural number < 10^72 with
+// the given words (see big.Int.Bits) for appendGroups, the least significant group
+// first. The groups are computed by repeated division of a copy of the words on
+// the stack, which avoids the allocations of big.Int arithmetic.
+func splitWords(groups *[maxBigIntGroups]uint64, words []big.Word) []uint64 {
+	// 10^72 < 2^240 fits into 8 words on 32 bit platforms
+	var buffer [8]big.Word
+	n := copy(buffer[:], words)
+	count := 0
+	for n > 0 {
+		var remainder uint
+		for j := n - 1; j >= 0; j-- {
+			var quotient uint
+			quotient, remainder = bits.Div(remainder, uint(buffer[j]), i万)
+			buffer[j] = big.Word(quotient)
 		}
-		if 0 < intMultiplier {
-			dst = append(dst, formatSmall(int(intMultiplier))...)
+		groups[count] = uint64(remainder)
+		count++
+		for n > 0 && buffer[n-1] == 0 {
+			n--
 		}
 	}
-	dst = append(dst, kanji...)
-	u.Sub(u, &totalValue)
-	return dst
+	return groups[:count]
 }
 
 var smallInts = [...]string{
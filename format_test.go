@@ -1,7 +1,10 @@
 package jnumber
 
 import (
+	"math"
 	"math/big"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -166,6 +169,11 @@ func TestFormatBigInt(t *testing.T) {
 			expectEqual(st, tc.Expected, actual)
 		})
 	}
+	for _, tc := range formatBigIntUnboundedCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			expectEqual(t, "", FormatBigInt(tc.Number))
+		})
+	}
 }
 
 func BenchmarkFormatBigInt(b *testing.B) {
@@ -177,3 +185,135 @@ func BenchmarkFormatBigInt(b *testing.B) {
 		})
 	}
 }
+
+var formatBigIntUnboundedCases = []formatBigIntTestCase{
+	{"一万無量大数", newTestBigInt(1, 72, 0)},
+	{"一万無量大数一", newTestBigInt(1, 72, 1)},
+	{"九千九百九十九万九千九百九十九無量大数", newTestBigInt(99_999_999, 68, 0)},
+	{"一無量大数無量大数", newTestBigInt(1, 136, 0)},
+	{"一無量大数一無量大数一", newTestBigInt(1, 136, 0).Add(newTestBigInt(1, 68, 1), newTestBigInt(1, 136, 0))},
+	{negativePrefix + "二億無量大数三", newTestBigInt(-2, 76, -3)},
+}
+
+func TestFormatBigIntErr(t *testing.T) {
+	for _, tc := range formatBigIntCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			actual, err := FormatBigIntErr(tc.Number)
+			expectErrNil(t, err)
+			expectEqual(t, tc.Expected, actual)
+		})
+	}
+	for _, tc := range formatBigIntUnboundedCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			actual, err := FormatBigIntErr(tc.Number)
+			expectErrIs(t, ErrOverflow, err)
+			expectEqual(t, "", actual)
+		})
+	}
+}
+
+func TestAppendBigInt(t *testing.T) {
+	for _, tc := range formatBigIntCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			actual, err := AppendBigInt([]byte("prefix "), tc.Number)
+			expectErrNil(t, err)
+			expectEqual(t, "prefix "+tc.Expected, string(actual))
+		})
+	}
+	actual, err := AppendBigInt([]byte("prefix "), newTestBigInt(1, 72, 0))
+	expectErrIs(t, ErrOverflow, err)
+	expectEqual(t, "prefix ", string(actual))
+}
+
+func TestAppendBigIntAllocs(t *testing.T) {
+	dst := make([]byte, 0, 1024)
+	for _, tc := range formatBigIntCases {
+		allocs := testing.AllocsPerRun(10, func() {
+			AppendBigInt(dst, tc.Number)
+		})
+		expectEqual(t, 0.0, allocs)
+	}
+}
+
+func TestFormatBigIntUnbounded(t *testing.T) {
+	for _, tc := range formatBigIntCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			expectEqual(t, tc.Expected, FormatBigIntUnbounded(tc.Number))
+		})
+	}
+	for _, tc := range formatBigIntUnboundedCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			expectEqual(t, tc.Expected, FormatBigIntUnbounded(tc.Number))
+		})
+	}
+}
+
+func TestFormatSerialBigInt(t *testing.T) {
+	for _, tc := range serialTestCases {
+		expectEqual(t, tc.String, FormatSerialBigInt(big.NewInt(tc.Value)))
+		expectEqual(t, "prefix "+tc.String, string(AppendSerialBigInt([]byte("prefix "), big.NewInt(tc.Value))))
+	}
+	for _, tc := range serialBigIntCases {
+		expectEqual(t, tc.Text, FormatSerialBigInt(tc.Expected))
+		expectEqual(t, "prefix "+tc.Text, string(AppendSerialBigInt([]byte("prefix "), tc.Expected)))
+	}
+}
+
+func TestFormatSerialWidth(t *testing.T) {
+	for _, tc := range []struct {
+		Value    int64
+		Width    int
+		Expected string
+	}{
+		{42, 4, "〇〇四二"},
+		{2023, 4, "二〇二三"},
+		{2023, 2, "二〇二三"},
+		{1, 2, "〇一"},
+		{0, 0, "〇"},
+		{0, 3, "〇〇〇"},
+		{-42, 4, negativePrefix + "〇〇四二"},
+		{math.MinInt64, 20, negativePrefix + "〇九二二三三七二〇三六八五四七七五八〇八"},
+	} {
+		expectEqual(t, tc.Expected, FormatSerialIntWidth(tc.Value, tc.Width))
+		expectEqual(t, "prefix "+tc.Expected, string(AppendSerialIntWidth([]byte("prefix "), tc.Value, tc.Width)))
+		if tc.Value >= 0 {
+			expectEqual(t, tc.Expected, FormatSerialUintWidth(uint64(tc.Value), tc.Width))
+			expectEqual(t, "prefix "+tc.Expected, string(AppendSerialUintWidth([]byte("prefix "), uint64(tc.Value), tc.Width)))
+		}
+	}
+	expectEqual(t, "一八四四六七四四〇七三七〇九五五一六一五", FormatSerialUintWidth(math.MaxUint64, 5))
+}
+
+func TestFormatUintGroups(t *testing.T) {
+	for g := uint64(1); g < i万; g++ {
+		for _, u := range []uint64{g, g * i万, g*i京 + g} {
+			actual, err := ParseUint(FormatUint(u))
+			expectErrNil(t, err)
+			expectEqual(t, u, actual)
+		}
+	}
+}
+
+func TestFormatBigIntUnboundedHuge(t *testing.T) {
+	var expected big.Int
+	expected.Exp(big.NewInt(10), big.NewInt(2000), nil)
+	expected.Add(&expected, big.NewInt(1))
+	str := FormatBigIntUnbounded(&expected)
+	// 10^2000 = 10^28·(10^68)^29
+	expectEqual(t, "一穣"+strings.Repeat("無量大数", 29)+"一", str)
+	actual, err := ParseBigInt(str)
+	expectErrNil(t, err)
+	expectEqual(t, expected.String(), actual.String())
+}
+
+func BenchmarkFormatBigIntUnbounded(b *testing.B) {
+	for _, exponent := range []int64{100, 1_000, 10_000} {
+		var i big.Int
+		i.Exp(big.NewInt(7), big.NewInt(exponent), nil)
+		b.Run(strconv.FormatInt(exponent, 10), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				FormatBigIntUnbounded(&i)
+			}
+		})
+	}
+}
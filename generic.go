@@ -0,0 +1,112 @@
+package jnumber
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// Signed is a constraint for all signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint for all unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint for all integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Parse returns the integer represented by the given japanese numerals like
+// ParseInt or ParseUint. Returns ErrOverflow if the number does not fit into T.
+// マイナス is only accepted for signed types.
+func Parse[T Integer](s string) (T, error) {
+	i, err := parseInteger[T](s, parseUintDefault)
+	if err != nil {
+		return 0, newParseError("Parse", s, err)
+	}
+	return i, nil
+}
+
+// ParseSerial returns the integer represented by the given serial japanese numerals
+// like ParseSerialInt or ParseSerialUint. Returns ErrOverflow if the number does
+// not fit into T. マイナス is only accepted for signed types.
+func ParseSerial[T Integer](s string) (T, error) {
+	i, err := parseInteger[T](s, parseSerialUint)
+	if err != nil {
+		return 0, newParseError("ParseSerial", s, err)
+	}
+	return i, nil
+}
+
+// parseInteger parses the absolute value with parse and checks the range of T.
+func parseInteger[T Integer](s string, parse func(string) (uint64, error)) (T, error) {
+	var zero T
+	if ^zero > 0 {
+		u, err := parse(s)
+		if err != nil {
+			return 0, err
+		}
+		if u > uint64(^zero) {
+			return 0, ErrOverflow
+		}
+		return T(u), nil
+	}
+	abs := strings.TrimPrefix(s, negativePrefix)
+	isNegative := s != abs
+	u, err := parse(abs)
+	if err != nil {
+		return 0, shiftError(err, len(s)-len(abs))
+	}
+	// the absolute value of the smallest number of T
+	limit := uint64(1) << (unsafe.Sizeof(zero)*8 - 1)
+	if isNegative {
+		if u > limit {
+			return 0, ErrOverflow
+		}
+		return T(-int64(u)), nil
+	}
+	if u >= limit {
+		return 0, ErrOverflow
+	}
+	return T(u), nil
+}
+
+// Append appends the given integer as japanese numerals to dst like AppendInt or
+// AppendUint.
+func Append[T Integer](dst []byte, i T) []byte {
+	if i < 0 {
+		return AppendInt(dst, int64(i))
+	}
+	return AppendUint(dst, uint64(i))
+}
+
+// Format returns the given integer as a string of japanese numerals like FormatInt
+// or FormatUint.
+func Format[T Integer](i T) string {
+	if i < 0 {
+		return FormatInt(int64(i))
+	}
+	return FormatUint(uint64(i))
+}
+
+// AppendSerial appends the given integer as serial japanese numerals to dst like
+// AppendSerialInt or AppendSerialUint.
+func AppendSerial[T Integer](dst []byte, i T) []byte {
+	if i < 0 {
+		return AppendSerialInt(dst, int64(i))
+	}
+	return AppendSerialUint(dst, uint64(i))
+}
+
+// FormatSerial returns the given integer as a string of serial japanese numerals
+// like FormatSerialInt or FormatSerialUint.
+func FormatSerial[T Integer](i T) string {
+	if i < 0 {
+		return FormatSerialInt(int64(i))
+	}
+	return FormatSerialUint(uint64(i))
+}
@@ -0,0 +1,91 @@
+package jnumber
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseGeneric(t *testing.T) {
+	for _, tc := range commonTestCases {
+		if tc.Value < math.MinInt32 || tc.Value > math.MaxInt32 {
+			continue
+		}
+		t.Run(tc.String, func(t *testing.T) {
+			actual, err := Parse[int32](tc.String)
+			expectErrNil(t, err)
+			expectEqual(t, int32(tc.Value), actual)
+		})
+	}
+}
+
+func TestParseGenericLimits(t *testing.T) {
+	testGenericLimits[int8](t, math.MinInt8, math.MaxInt8)
+	testGenericLimits[int16](t, math.MinInt16, math.MaxInt16)
+	testGenericLimits[int32](t, math.MinInt32, math.MaxInt32)
+	testGenericLimits[int64](t, math.MinInt64, math.MaxInt64)
+	testGenericLimits[uint8](t, 0, math.MaxUint8)
+	testGenericLimits[uint16](t, 0, math.MaxUint16)
+	testGenericLimits[uint32](t, 0, math.MaxUint32)
+}
+
+func testGenericLimits[T Integer](t *testing.T, min, max T) {
+	t.Helper()
+	actual, err := Parse[T](Format(max))
+	expectErrNil(t, err)
+	expectEqual(t, max, actual)
+	_, err = Parse[T](FormatUint(uint64(max) + 1))
+	expectErrIs(t, ErrOverflow, err)
+	actual, err = ParseSerial[T](FormatSerial(max))
+	expectErrNil(t, err)
+	expectEqual(t, max, actual)
+	_, err = ParseSerial[T](FormatSerialUint(uint64(max) + 1))
+	expectErrIs(t, ErrOverflow, err)
+	if min < 0 {
+		actual, err = Parse[T](Format(min))
+		expectErrNil(t, err)
+		expectEqual(t, min, actual)
+		_, err = Parse[T](negativePrefix + FormatUint(uint64(max)+2))
+		expectErrIs(t, ErrOverflow, err)
+		actual, err = ParseSerial[T](FormatSerial(min))
+		expectErrNil(t, err)
+		expectEqual(t, min, actual)
+	} else {
+		_, err = Parse[T](negativePrefix + "一")
+		expectErrIs(t, ErrUnexpectedRune, err)
+	}
+}
+
+func TestParseGenericUint64(t *testing.T) {
+	actual, err := Parse[uint64](FormatUint(math.MaxUint64))
+	expectErrNil(t, err)
+	expectEqual(t, uint64(math.MaxUint64), actual)
+}
+
+type testCustomInt int16
+
+func TestParseGenericCustomType(t *testing.T) {
+	actual, err := Parse[testCustomInt]("マイナス三万")
+	expectErrNil(t, err)
+	expectEqual(t, testCustomInt(-30000), actual)
+	expectEqual(t, "マイナス三万", Format(actual))
+	expectEqual(t, "二〇二三", string(AppendSerial(nil, testCustomInt(2023))))
+}
+
+func TestFormatGeneric(t *testing.T) {
+	for _, tc := range commonTestCases {
+		t.Run(tc.String, func(t *testing.T) {
+			expectEqual(t, tc.String, Format(tc.Value))
+			expectEqual(t, "prefix "+tc.String, string(Append([]byte("prefix "), tc.Value)))
+		})
+	}
+	for _, tc := range serialTestCases {
+		t.Run(tc.String, func(t *testing.T) {
+			expectEqual(t, tc.String, FormatSerial(tc.Value))
+		})
+	}
+}
+
+func TestParseGenericError(t *testing.T) {
+	testParseError(t, commonErrorCases, Parse[int16])
+	testParseError(t, parseSerialErrorCases, ParseSerial[uint8])
+}
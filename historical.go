@@ -0,0 +1,218 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+const (
+	i廿 = 20
+	i卅 = 30
+	i卌 = 40
+	i皕 = 200
+)
+
+// historicalRunes contains all runes that are only accepted in historical mode.
+const historicalRunes = "廿卅卌皕有"
+
+// HistoricalValueOf returns the numeric value of a single kanji like ValueOf, but
+// also knows the historical forms 廿 (20), 卅 (30), 卌 (40) and 皕 (200).
+func HistoricalValueOf(r rune) (value uint64, ok bool) {
+	switch r {
+	case '廿':
+		return i廿, true
+	case '卅':
+		return i卅, true
+	case '卌':
+		return i卌, true
+	case '皕':
+		return i皕, true
+	default:
+		return ValueOf(r)
+	}
+}
+
+// ParseHistoricalInt returns the integer represented by the given japanese numerals.
+// In addition to the numerals supported by ParseInt, the historical forms
+// 廿 (20), 卅 (30), 卌 (40), 皕 (200) and the classical 有 between a unit and the
+// following digits (十有五 for 15) are accepted.
+func ParseHistoricalInt(s string) (int64, error) {
+	i, err := parseHistoricalInt(s)
+	if err != nil {
+		return 0, newParseError("ParseHistoricalInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseHistoricalUint returns the unsigned integer represented by the given japanese
+// numerals. See ParseHistoricalInt for the supported historical forms.
+func ParseHistoricalUint(s string) (uint64, error) {
+	u, err := parseHistoricalUint(s)
+	if err != nil {
+		return 0, newParseError("ParseHistoricalUint", s, err)
+	}
+	return u, nil
+}
+
+// ParseHistoricalBigInt returns the integer represented by the given japanese numerals.
+// See ParseHistoricalInt for the supported historical forms.
+func ParseHistoricalBigInt(s string) (*big.Int, error) {
+	i, err := parseHistoricalBigInt(s)
+	if err != nil {
+		return nil, newParseError("ParseHistoricalBigInt", s, err)
+	}
+	return i, nil
+}
+
+func parseHistoricalInt(s string) (int64, error) {
+	normalized, err := fromHistorical(s)
+	if err != nil {
+		return 0, err
+	}
+	i, err := parseInt(normalized, nil)
+	if err != nil {
+		return 0, historicalError(s, err)
+	}
+	return i, nil
+}
+
+func parseHistoricalUint(s string) (uint64, error) {
+	normalized, err := fromHistorical(s)
+	if err != nil {
+		return 0, err
+	}
+	u, err := parseUint(normalized, nil)
+	if err != nil {
+		return 0, historicalError(s, err)
+	}
+	return u, nil
+}
+
+func parseHistoricalBigInt(s string) (*big.Int, error) {
+	normalized, err := fromHistorical(s)
+	if err != nil {
+		return nil, err
+	}
+	i, err := parseBigInt(normalized, nil)
+	if err != nil {
+		return nil, historicalError(s, err)
+	}
+	return i, nil
+}
+
+// historicalError moves the position of an error in the result of fromHistorical
+// to the corresponding rune of the original string s.
+func historicalError(s string, err error) error {
+	return normalizedError(s, err, func(r rune) int {
+		switch r {
+		case '廿', '卅', '卌', '皕':
+			return 2 * utf8KanjiBytes
+		case '有':
+			return 0
+		default:
+			return utf8.RuneLen(r)
+		}
+	})
+}
+
+// fromHistorical replaces all historical forms with their modern equivalent.
+func fromHistorical(s string) (string, error) {
+	if !strings.ContainsAny(s, historicalRunes) {
+		return s, nil
+	}
+	var b strings.Builder
+	b.Grow(len(s) + len(s)/2)
+	var last rune
+	for i, r := range s {
+		switch r {
+		case '廿':
+			b.WriteString("二十")
+		case '卅':
+			b.WriteString("三十")
+		case '卌':
+			b.WriteString("四十")
+		case '皕':
+			b.WriteString("二百")
+		case '有':
+			// 有 is only valid between a unit and the following digits
+			if !isHistoricalUnit(last) || i+len("有") == len(s) {
+				return "", errorAt(i, ErrInvalidSequence)
+			}
+			if next, _ := utf8.DecodeRuneInString(s[i+len("有"):]); next == '有' {
+				return "", errorAt(i+len("有"), ErrInvalidSequence)
+			}
+		default:
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String(), nil
+}
+
+// isHistoricalUnit returns true if the given rune is a unit or the last rune of a
+// multi kanji unit.
+func isHistoricalUnit(r rune) bool {
+	if value, ok := HistoricalValueOf(r); ok {
+		return value >= i十
+	}
+	return strings.ContainsRune(commonBigIntRunes+"沙祇他議数", r)
+}
+
+// AppendHistoricalInt appends the given integer as japanese numerals to dst and uses
+// 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func AppendHistoricalInt(dst []byte, i int64) []byte {
+	var buffer [128]byte
+	return appendHistorical(dst, AppendInt(buffer[:0], i))
+}
+
+// AppendHistoricalUint appends the given unsigned integer as japanese numerals to dst
+// and uses 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func AppendHistoricalUint(dst []byte, u uint64) []byte {
+	var buffer [128]byte
+	return appendHistorical(dst, AppendUint(buffer[:0], u))
+}
+
+// FormatHistoricalInt returns the given integer as a string of Japanese numerals
+// and uses 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func FormatHistoricalInt(i int64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendHistoricalInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatHistoricalUint returns the given unsigned integer as a string of Japanese
+// numerals and uses 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func FormatHistoricalUint(u uint64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendHistoricalUint(dst, u)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// appendHistorical appends src to dst and replaces 二十, 三十 and 四十 with their
+// historical forms. In the output of the formatter, a digit in front of 十 is
+// always its multiplier, so a simple replacement is sufficient.
+func appendHistorical(dst []byte, src []byte) []byte {
+	for i := 0; i < len(src); {
+		if i+2*utf8KanjiBytes <= len(src) && string(src[i+utf8KanjiBytes:i+2*utf8KanjiBytes]) == "十" {
+			switch string(src[i : i+utf8KanjiBytes]) {
+			case "二":
+				dst = append(dst, "廿"...)
+				i += 2 * utf8KanjiBytes
+				continue
+			case "三":
+				dst = append(dst, "卅"...)
+				i += 2 * utf8KanjiBytes
+				continue
+			case "四":
+				dst = append(dst, "卌"...)
+				i += 2 * utf8KanjiBytes
+				continue
+			}
+		}
+		dst = append(dst, src[i])
+		i++
+	}
+	return dst
+}
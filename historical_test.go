@@ -0,0 +1,85 @@
+package jnumber
+
+import (
+	"testing"
+)
+
+var historicalTestCases = []testCase[int64]{
+	{"廿", 20},
+	{"卅", 30},
+	{"卌", 40},
+	{"皕", 200},
+	{"廿一", 21},
+	{"卅五", 35},
+	{"百廿", 120},
+	{"皕卌", 240},
+	{"十有五", 15},
+	{"三百有五", 305},
+	{"卅万", 300_000},
+	{"一万有五", 10_005},
+	{negativePrefix + "廿三", -23},
+}
+
+var historicalErrorCases = []parseErrorTestCase{
+	{"", ErrEmpty},
+	{"有", ErrInvalidSequence},
+	{"有五", ErrInvalidSequence},
+	{"五有", ErrInvalidSequence},
+	{"十有", ErrInvalidSequence},
+	{"十有有五", ErrInvalidSequence},
+	{"二廿", ErrInvalidSequence},
+	{"廿十", ErrInvalidSequence},
+	{"廿卅", ErrInvalidSequence},
+}
+
+var formatHistoricalTestCases = []testCase[int64]{
+	{"十九", 19},
+	{"廿", 20},
+	{"廿一", 21},
+	{"卅", 30},
+	{"卌九", 49},
+	{"五十", 50},
+	{"百廿三", 123},
+	{"廿万卅", 200_030},
+	{negativePrefix + "卌", -40},
+}
+
+func TestParseHistoricalInt(t *testing.T) {
+	testParse(t, commonTestCases, ParseHistoricalInt)
+	testParse(t, historicalTestCases, ParseHistoricalInt)
+}
+
+func TestParseHistoricalIntError(t *testing.T) {
+	testParseError(t, commonErrorCases, ParseHistoricalInt)
+	testParseError(t, historicalErrorCases, ParseHistoricalInt)
+}
+
+func TestParseHistoricalBigInt(t *testing.T) {
+	actual, err := ParseHistoricalBigInt("廿垓有五")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(20, 20, 5).String(), actual.String())
+}
+
+func TestParseUintRejectsHistorical(t *testing.T) {
+	testParseError(t, []parseErrorTestCase{
+		{"廿", ErrUnexpectedRune},
+		{"十有五", ErrUnexpectedRune},
+	}, ParseUint)
+}
+
+func TestFormatHistoricalInt(t *testing.T) {
+	testFormat(t, formatHistoricalTestCases, FormatHistoricalInt)
+	testAppend(t, formatHistoricalTestCases, AppendHistoricalInt)
+}
+
+func TestHistoricalValueOf(t *testing.T) {
+	for _, k := range uint64Kanjis {
+		expectedValue, expectedOk := ValueOf(k)
+		actualValue, actualOk := HistoricalValueOf(k)
+		expectEqual(t, expectedValue, actualValue)
+		expectEqual(t, expectedOk, actualOk)
+	}
+	value, ok := HistoricalValueOf('卅')
+	expectEqual(t, uint64(30), value)
+	expectEqual(t, true, ok)
+}
@@ -0,0 +1,293 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// KoreanStyle defines the characters used to format Sino-Korean numerals.
+type KoreanStyle int
+
+const (
+	// KoreanHangul uses hangul and separates the groups of units >= 만 with a
+	// space: 삼만 오천
+	KoreanHangul KoreanStyle = iota
+	// KoreanHanja uses hanja without spaces: 三萬五千
+	KoreanHanja
+)
+
+const (
+	koreanNegativePrefix = "마이너스"
+	// koreanMaxDigits is the number of digits of the biggest supported number.
+	// The biggest unit is 양/穰 (10^28), because the next unit 구 (10^32) is
+	// indistinguishable from the digit 구 (9).
+	koreanMaxDigits = 32
+)
+
+// koreanNumerals contains the characters of a KoreanStyle.
+type koreanNumerals struct {
+	Digits    [10]string
+	Units     [4]string
+	BigUnits  [koreanMaxDigits/4 - 1]string
+	Separator string
+	// the groups of four digits like groupTable, which are built on first use
+	groupsOnce   sync.Once
+	groupTable   string
+	groupOffsets *[i万 + 1]uint32
+}
+
+var koreanStyles = [...]koreanNumerals{
+	KoreanHangul: {
+		Digits:    [...]string{"영", "일", "이", "삼", "사", "오", "육", "칠", "팔", "구"},
+		Units:     [...]string{"", "십", "백", "천"},
+		BigUnits:  [...]string{"만", "억", "조", "경", "해", "자", "양"},
+		Separator: " ",
+	},
+	KoreanHanja: {
+		Digits:   [...]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		Units:    [...]string{"", "十", "百", "千"},
+		BigUnits: [...]string{"萬", "億", "兆", "京", "垓", "秭", "穰"},
+	},
+}
+
+// koreanRules contains the rules for Sino-Korean numerals: 만 does not need the
+// multiplier 일 (만 for 10^4, but 일억 for 10^8).
+var koreanRules = numeralRules{
+	System:      Myriad,
+	ImplicitOne: 1,
+}
+
+// ParseKoreanInt returns the integer represented by the given Sino-Korean numerals.
+// Accepts hangul and hanja, a space after units >= 만 and 마이너스 as a negative
+// prefix.
+func ParseKoreanInt(s string) (int64, error) {
+	abs, isNegative := strings.CutPrefix(s, koreanNegativePrefix)
+	parser := newBigIntParser(nil, &koreanRules, nil)
+	err := parseKorean(&parser, abs)
+	if err == nil {
+		var sum uint64
+		if sum, err = parser.uint64(); err == nil {
+			var i int64
+			if i, err = toInt64(sum, isNegative); err == nil {
+				return i, nil
+			}
+		}
+	}
+	return 0, newParseError("ParseKoreanInt", s, shiftError(err, len(s)-len(abs)))
+}
+
+// ParseKoreanUint returns the unsigned integer represented by the given Sino-Korean
+// numerals. See ParseKoreanInt for the accepted characters.
+func ParseKoreanUint(s string) (uint64, error) {
+	parser := newBigIntParser(nil, &koreanRules, nil)
+	err := parseKorean(&parser, s)
+	if err == nil {
+		var u uint64
+		if u, err = parser.uint64(); err == nil {
+			return u, nil
+		}
+	}
+	return 0, newParseError("ParseKoreanUint", s, err)
+}
+
+// ParseKoreanBigInt returns the integer represented by the given Sino-Korean
+// numerals. See ParseKoreanInt for the accepted characters.
+func ParseKoreanBigInt(s string) (*big.Int, error) {
+	abs, isNegative := strings.CutPrefix(s, koreanNegativePrefix)
+	parser := newBigIntParser(new(big.Int), &koreanRules, nil)
+	if err := parseKorean(&parser, abs); err != nil {
+		return nil, newParseError("ParseKoreanBigInt", s, shiftError(err, len(s)-len(abs)))
+	}
+	result := parser.bigInt()
+	if isNegative {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+// parseKorean parses the given Sino-Korean numerals without a sign with p.
+func parseKorean(p *bigIntParser, s string) error {
+	if s == "" {
+		return ErrEmpty
+	}
+	// end of the last unit >= 만
+	unitEnd := -1
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == ' ' && unitEnd == i && i+size < len(s) {
+			// groups of units >= 만 are separated by spaces
+			i += size
+			continue
+		}
+		value, k, ok := koreanValueOf(r)
+		var err error
+		switch {
+		case !ok:
+			return errorAt(i, checkUnexpectedRune(s[i:]))
+		case k >= 0:
+			err = p.endSegmentWith(k)
+			unitEnd = i + size
+		case value > 0:
+			err = p.push(value)
+		case size < len(s):
+			// zero is only valid if it is the only rune
+			err = ErrInvalidSequence
+		}
+		if err != nil {
+			return errorAt(i, err)
+		}
+		i += size
+	}
+	return p.end()
+}
+
+// koreanValueOf returns the value of a single Sino-Korean numeral < 만 or the index
+// in myriadUnits of a unit >= 만, which is -1 for the numerals < 만.
+func koreanValueOf(r rune) (value uint64, k int, ok bool) {
+	switch r {
+	case '영', '공', '零', '〇':
+		return 0, -1, true
+	case '일', '一':
+		return 1, -1, true
+	case '이', '二':
+		return 2, -1, true
+	case '삼', '三':
+		return 3, -1, true
+	case '사', '四':
+		return 4, -1, true
+	case '오', '五':
+		return 5, -1, true
+	case '육', '륙', '六':
+		return 6, -1, true
+	case '칠', '七':
+		return 7, -1, true
+	case '팔', '八':
+		return 8, -1, true
+	case '구', '九':
+		return 9, -1, true
+	case '십', '十':
+		return i十, -1, true
+	case '백', '百':
+		return i百, -1, true
+	case '천', '千':
+		return i千, -1, true
+	case '만', '萬', '万':
+		return 0, 0, true
+	case '억', '億':
+		return 0, 1, true
+	case '조', '兆':
+		return 0, 2, true
+	case '경', '京':
+		return 0, 3, true
+	case '해', '垓':
+		return 0, 4, true
+	case '자', '秭', '𥝱':
+		return 0, 5, true
+	case '양', '穰', '穣':
+		return 0, 6, true
+	default:
+		return 0, -1, false
+	}
+}
+
+// AppendKoreanInt appends the given integer as Sino-Korean numerals in the given
+// style to dst.
+func AppendKoreanInt(dst []byte, i int64, style KoreanStyle) []byte {
+	var u uint64
+	if i < 0 {
+		u = uint64(-i)
+		dst = append(dst, koreanNegativePrefix...)
+	} else {
+		u = uint64(i)
+	}
+	return AppendKoreanUint(dst, u, style)
+}
+
+// AppendKoreanUint appends the given unsigned integer as Sino-Korean numerals in the
+// given style to dst.
+func AppendKoreanUint(dst []byte, u uint64, style KoreanStyle) []byte {
+	var groups [5]uint64
+	return appendKorean(dst, splitUint64(&groups, u), &koreanStyles[style])
+}
+
+// AppendKoreanBigInt appends the given big integer as Sino-Korean numerals in the
+// given style to dst. Returns ErrOverflow if |i| >= 10^32.
+func AppendKoreanBigInt(dst []byte, i *big.Int, style KoreanStyle) ([]byte, error) {
+	initBigIntsOnce.Do(initBigInts)
+	if i.CmpAbs(myriadUnits[koreanMaxDigits/4-1].Value) >= 0 {
+		return dst, ErrOverflow
+	}
+	if i.Sign() < 0 {
+		dst = append(dst, koreanNegativePrefix...)
+	}
+	var groups [maxBigIntGroups]uint64
+	return appendKorean(dst, splitWords(&groups, i.Bits()), &koreanStyles[style]), nil
+}
+
+// FormatKoreanInt returns the given integer as a string of Sino-Korean numerals in
+// the given style.
+func FormatKoreanInt(i int64, style KoreanStyle) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendKoreanInt(dst, i, style)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatKoreanUint returns the given unsigned integer as a string of Sino-Korean
+// numerals in the given style.
+func FormatKoreanUint(u uint64, style KoreanStyle) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = AppendKoreanUint(dst, u, style)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// FormatKoreanBigInt returns the given big integer as a string of Sino-Korean
+// numerals in the given style. Returns ErrOverflow if |i| >= 10^32.
+func FormatKoreanBigInt(i *big.Int, style KoreanStyle) (string, error) {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst, err := AppendKoreanBigInt(dst, i, style)
+	if err != nil {
+		return "", err
+	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst)), nil
+}
+
+// appendKorean appends the number with the given groups of four digits (see
+// appendGroups) as Sino-Korean numerals to dst. Like in Japanese, 일 is omitted in
+// front of 십, 백 and 천, but in Korean it is also omitted in front of 만.
+func appendKorean(dst []byte, groups []uint64, numerals *koreanNumerals) []byte {
+	if len(groups) == 0 {
+		return append(dst, numerals.Digits[0]...)
+	}
+	numerals.groupsOnce.Do(func() {
+		table, offsets := initGroups(numerals.Digits[:], numerals.Units[:])
+		numerals.groupTable, numerals.groupOffsets = table, &offsets
+	})
+	for k := len(groups) - 1; k >= 0; k-- {
+		g := groups[k]
+		if g == 0 {
+			continue
+		}
+		if g > 1 || k != 1 {
+			dst = append(dst, numerals.groupTable[numerals.groupOffsets[g]:numerals.groupOffsets[g+1]]...)
+		}
+		if k > 0 {
+			dst = append(dst, numerals.BigUnits[k-1]...)
+			if hasNonZeroGroup(groups[:k]) {
+				dst = append(dst, numerals.Separator...)
+			}
+		}
+	}
+	return dst
+}
+
+func hasNonZeroGroup(groups []uint64) bool {
+	for _, g := range groups {
+		if g > 0 {
+			return true
+		}
+	}
+	return false
+}
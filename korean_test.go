@@ -0,0 +1,120 @@
+package jnumber
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var koreanHangulTestCases = []testCase[int64]{
+	{"영", 0},
+	{"일", 1},
+	{"십", 10},
+	{"십오", 15},
+	{"이십", 20},
+	{"백", 100},
+	{"백일", 101},
+	{"천", 1_000},
+	{"만", 10_000},
+	{"만 일", 10_001},
+	{"십만", 100_000},
+	{"십일만", 110_000},
+	{"삼만 오천", 35_000},
+	{"일억", i億},
+	{"일억 만", i億 + i万},
+	{"일억 이천삼백사십오만 육천칠백팔십구", 123_456_789},
+	{"일조", i兆},
+	{"구백이십이경 삼천삼백칠십이조 삼백육십팔억 오천사백칠십칠만 오천팔백칠", math.MaxInt64},
+	{koreanNegativePrefix + "구백이십이경 삼천삼백칠십이조 삼백육십팔억 오천사백칠십칠만 오천팔백팔", math.MinInt64},
+}
+
+var koreanHanjaTestCases = []testCase[int64]{
+	{"零", 0},
+	{"十五", 15},
+	{"萬", 10_000},
+	{"三萬五千", 35_000},
+	{"一億萬", i億 + i万},
+	{"一億二千三百四十五萬六千七百八十九", 123_456_789},
+	{koreanNegativePrefix + "二十", -20},
+}
+
+var koreanParseTestCases = []testCase[int64]{
+	{"삼만오천", 35_000},
+	{"일만", 10_000},
+	{"일천", 1_000},
+	{"三万五千", 35_000},
+	{"삼萬 오千", 35_000},
+	{"공", 0},
+	{"십륙", 16},
+}
+
+var koreanErrorCases = []parseErrorTestCase{
+	{"", ErrEmpty},
+	{"억", ErrInvalidSequence},
+	{"일일", ErrInvalidSequence},
+	{"십백", ErrInvalidSequence},
+	{"만만", ErrInvalidSequence},
+	{"일영", ErrInvalidSequence},
+	{"영일", ErrInvalidSequence},
+	{" 삼", ErrUnexpectedRune},
+	{"삼 만", ErrUnexpectedRune},
+	{"삼만 ", ErrUnexpectedRune},
+	{"삼만  오천", ErrUnexpectedRune},
+	{"a", ErrUnexpectedRune},
+	{"천팔백사십사경 육천칠백사십사조 칠백삼십칠억 구백오십오만 천육백십오", ErrOverflow},
+}
+
+func TestParseKoreanInt(t *testing.T) {
+	testParse(t, koreanHangulTestCases, ParseKoreanInt)
+	testParse(t, koreanHanjaTestCases, ParseKoreanInt)
+	testParse(t, koreanParseTestCases, ParseKoreanInt)
+}
+
+func TestParseKoreanIntError(t *testing.T) {
+	testParseError(t, koreanErrorCases, ParseKoreanInt)
+}
+
+func TestParseKoreanUint(t *testing.T) {
+	testParse(t, []testCase[uint64]{
+		{"천팔백사십사경 육천칠백사십사조 칠백삼십칠억 구백오십오만 천육백십오", math.MaxUint64},
+	}, ParseKoreanUint)
+}
+
+func TestFormatKoreanInt(t *testing.T) {
+	testFormat(t, koreanHangulTestCases, func(i int64) string { return FormatKoreanInt(i, KoreanHangul) })
+	testFormat(t, koreanHanjaTestCases, func(i int64) string { return FormatKoreanInt(i, KoreanHanja) })
+	testAppend(t, koreanHangulTestCases, func(dst []byte, i int64) []byte { return AppendKoreanInt(dst, i, KoreanHangul) })
+}
+
+func TestFormatKoreanBigInt(t *testing.T) {
+	actual, err := FormatKoreanBigInt(newTestBigInt(2, 28, 10_000), KoreanHangul)
+	expectErrNil(t, err)
+	expectEqual(t, "이양 만", actual)
+	actual, err = FormatKoreanBigInt(newTestBigInt(1, 32, 0), KoreanHanja)
+	expectErrIs(t, ErrOverflow, err)
+	expectEqual(t, "", actual)
+}
+
+func TestFormatParseKoreanRandom(t *testing.T) {
+	var limit big.Int
+	limit.Exp(big.NewInt(10), big.NewInt(koreanMaxDigits), nil)
+	random := rand.New(rand.NewSource(0))
+	for i := 0; i < 100_000; i++ {
+		var expected big.Int
+		expected.Rand(random, &limit)
+		expected.Rsh(&expected, uint(random.Intn(106)))
+		style := KoreanStyle(i % len(koreanStyles))
+		str, err := FormatKoreanBigInt(&expected, style)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		actual, err := ParseKoreanBigInt(str)
+		if err != nil {
+			t.Fatalf("err: %v, str: %s", err, str)
+		}
+		if actual.Cmp(&expected) != 0 {
+			t.Fatalf("expected: %s, actual: %s, str: %s", &expected, actual, str)
+		}
+	}
+}
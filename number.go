@@ -4,9 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 // utf8KanjiBytes is the number of bytes per kanji (at least the ones that are relevant for this package).
@@ -50,6 +51,94 @@ func (e *UnexpectedRuneError) Is(err error) bool {
 	return ok && castedErr.Actual == e.Actual && castedErr.Expected == e.Expected
 }
 
+// ParseError is returned by all Parse* functions. It records the failed function,
+// the input and the position of the error, similar to strconv.NumError. Use
+// errors.Is to check for the wrapped sentinel errors like ErrInvalidSequence.
+type ParseError struct {
+	// Func is the name of the failing function, e.g. "ParseUint".
+	Func string
+	// Input is the parsed string.
+	Input string
+	// Offset is the byte offset of the rune that caused the error or len(Input) if
+	// the error does not refer to a single rune, e.g. ErrEmpty, ErrEOF or
+	// ErrOverflow for the total.
+	Offset int
+	// RuneOffset is the number of runes in front of Offset.
+	RuneOffset int
+	// Rune is the rune at Offset or 0 if Offset is len(Input).
+	Rune rune
+	// Err is the reason for the failure, e.g. ErrInvalidSequence.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	msg := "jnumber." + e.Func + ": parsing " + strconv.Quote(e.Input) + ": " + e.Err.Error()
+	if e.Offset < len(e.Input) {
+		msg += " at offset " + strconv.Itoa(e.Offset)
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// errorAt returns an incomplete ParseError for the given byte offset, which is
+// completed by newParseError. Errors that already have a position are returned
+// unchanged.
+func errorAt(offset int, err error) error {
+	if _, ok := err.(*ParseError); ok {
+		return err
+	}
+	return &ParseError{Offset: offset, Err: err}
+}
+
+// shiftError moves the position of an error from errorAt by the given number of
+// bytes, e.g. to account for a prefix that was removed before parsing.
+func shiftError(err error, shift int) error {
+	if e, ok := err.(*ParseError); ok {
+		e.Offset += shift
+	}
+	return err
+}
+
+// newParseError completes the given error of a parse function. Errors without a
+// position refer to the end of the input.
+func newParseError(fn string, input string, err error) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		e = &ParseError{Offset: len(input), Err: err}
+	}
+	e.Func = fn
+	e.Input = input
+	e.RuneOffset = utf8.RuneCountInString(input[:e.Offset])
+	if e.Offset < len(input) {
+		e.Rune, _ = utf8.DecodeRuneInString(input[e.Offset:])
+	}
+	return e
+}
+
+// normalizedError moves the position of an error in a normalized version of s to
+// the corresponding rune of s. normalizedSize returns the number of bytes of the
+// normalized form of a rune.
+func normalizedError(s string, err error, normalizedSize func(r rune) int) error {
+	e, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	position := 0
+	for i, r := range s {
+		size := normalizedSize(r)
+		if position+size > e.Offset {
+			e.Offset = i
+			return e
+		}
+		position += size
+	}
+	e.Offset = len(s)
+	return e
+}
+
 const (
 	i零 = 0
 	i一 = 1
@@ -91,40 +180,58 @@ const (
 )
 
 var (
-	b零                  big.Int
-	b一                  big.Int
-	b二                  big.Int
-	b三                  big.Int
-	b四                  big.Int
-	b五                  big.Int
-	b六                  big.Int
-	b七                  big.Int
-	b八                  big.Int
-	b九                  big.Int
-	b十                  big.Int // 10^1
-	b百                  big.Int // 10^2
-	b千                  big.Int // 10^3
-	b万                  big.Int // 10^4
-	b億                  big.Int // 10^8
-	b兆                  big.Int // 10^12
-	b京                  big.Int // 10^16
-	b垓                  big.Int // 10^20
-	b秭                  big.Int // 10^24
-	b穣                  big.Int // 10^28
-	b溝                  big.Int // 10^32
-	b澗                  big.Int // 10^36
-	b正                  big.Int // 10^40
-	b載                  big.Int // 10^44
-	b極                  big.Int // 10^48
-	b恒河沙                big.Int // 10^52
-	b阿僧祇                big.Int // 10^56
-	b那由他                big.Int // 10^60
-	b不可思議               big.Int // 10^64
-	b無量大数               big.Int // 10^68
-	maxBigIntMultiplier big.Int
-	initBigIntsOnce     sync.Once
+	b零              big.Int
+	b一              big.Int
+	b二              big.Int
+	b三              big.Int
+	b四              big.Int
+	b五              big.Int
+	b六              big.Int
+	b七              big.Int
+	b八              big.Int
+	b九              big.Int
+	b十              big.Int // 10^1
+	b百              big.Int // 10^2
+	b千              big.Int // 10^3
+	b万              big.Int // 10^4
+	b億              big.Int // 10^8
+	b兆              big.Int // 10^12
+	b京              big.Int // 10^16
+	b垓              big.Int // 10^20
+	b秭              big.Int // 10^24
+	b穣              big.Int // 10^28
+	b溝              big.Int // 10^32
+	b澗              big.Int // 10^36
+	b正              big.Int // 10^40
+	b載              big.Int // 10^44
+	b極              big.Int // 10^48
+	b恒河沙            big.Int // 10^52
+	b阿僧祇            big.Int // 10^56
+	b那由他            big.Int // 10^60
+	b不可思議           big.Int // 10^64
+	b無量大数           big.Int // 10^68
+	maxBigIntLimit  big.Int // 10^72, the first number that needs a multiplier > 9999 for 無量大数
+	initBigIntsOnce sync.Once
 )
 
+// bigUnit is a unit >= 万 together with its value in a specific unit system.
+type bigUnit struct {
+	Kanji string
+	Value *big.Int
+}
+
+// index秭 is the index of 秭 in myriadUnits.
+const index秭 = 5
+
+// myriadUnits contains all units >= 万 of the default unit system in ascending order.
+var myriadUnits = [...]bigUnit{
+	{"万", &b万}, {"億", &b億}, {"兆", &b兆}, {"京", &b京},
+	{"垓", &b垓}, {"秭", &b秭}, {"穣", &b穣}, {"溝", &b溝},
+	{"澗", &b澗}, {"正", &b正}, {"載", &b載}, {"極", &b極},
+	{"恒河沙", &b恒河沙}, {"阿僧祇", &b阿僧祇}, {"那由他", &b那由他}, {"不可思議", &b不可思議},
+	{"無量大数", &b無量大数},
+}
+
 func initBigInts() {
 	b零.SetUint64(i零)
 	b一.SetUint64(i一)
@@ -139,26 +246,12 @@ func initBigInts() {
 	b十.SetUint64(i十)
 	b百.SetUint64(i百)
 	b千.SetUint64(i千)
-	b万.SetUint64(i万)
-	b億.SetUint64(i億)
-	b兆.SetUint64(i兆)
-	b京.SetUint64(i京)
 	var ten big.Int
 	ten.SetUint64(10)
-	b垓.Exp(&ten, big.NewInt(20), nil)
-	b秭.Exp(&ten, big.NewInt(24), nil)
-	b穣.Exp(&ten, big.NewInt(28), nil)
-	b溝.Exp(&ten, big.NewInt(32), nil)
-	b澗.Exp(&ten, big.NewInt(36), nil)
-	b正.Exp(&ten, big.NewInt(40), nil)
-	b載.Exp(&ten, big.NewInt(44), nil)
-	b極.Exp(&ten, big.NewInt(48), nil)
-	b恒河沙.Exp(&ten, big.NewInt(52), nil)
-	b阿僧祇.Exp(&ten, big.NewInt(56), nil)
-	b那由他.Exp(&ten, big.NewInt(60), nil)
-	b不可思議.Exp(&ten, big.NewInt(64), nil)
-	b無量大数.Exp(&ten, big.NewInt(68), nil)
-	maxBigIntMultiplier.SetUint64(9999)
+	for k := range myriadUnits {
+		myriadUnits[k].Value.Exp(&ten, big.NewInt(int64(Myriad.exponent(k))), nil)
+	}
+	maxBigIntLimit.Mul(&b無量大数, &b万)
 }
 
 var (
@@ -195,7 +288,7 @@ var (
 func ValueOf(r rune) (value uint64, ok bool) {
 	hash := runeValuesPerfectHash(r)
 	val := runeValues[hash]
-	return val.Value, val.Rune == r
+	return val.Value, val.Rune == r && r != 0
 }
 
 type runeValue struct {
@@ -217,9 +310,13 @@ var runeValues = [...]runeValue{
 	{0, 0}, {'柒', i柒}, {'貳', i貳}, {0, 0}, {'億', i億}, {0, 0}, {'兆', i兆},
 }
 
+// runeValuesMultiplier is the multiplier of runeValuesPerfectHash. The table can be
+// regenerated with newAlphabet.
+const runeValuesMultiplier = 2995700326
+
 // runeValuesPerfectHash is a perfect hash function for all kanji with numeric values.
 func runeValuesPerfectHash(r rune) int {
-	return int(((2995700326 * uint32(r)) >> 26) & 0b111111)
+	return int(((runeValuesMultiplier * uint32(r)) >> 26) & 0b111111)
 }
 
 // ToDaiji replaces some kanji with current daiji (大字).
@@ -233,18 +330,9 @@ func FromDaiji() *strings.Replacer {
 }
 
 const (
-	commonIntRunes    = "零〇一二三四五六七八九十百千万億兆京"
-	commonBigIntRunes = "垓秭穣溝澗正載極"
+	commonBigIntRunes = "垓秭𥝱穣溝澗正載極"
 	daijiRunes        = "壱弐参拾萬"
 	obsoletDajiRunes  = "壹貳參肆伍陸柒漆捌玖佰阡仟"
-	patternInt        = "(?:[" + commonIntRunes + daijiRunes + obsoletDajiRunes + "])+"
-	patternBigInt     = "(?:[" + commonIntRunes + daijiRunes + obsoletDajiRunes + commonBigIntRunes +
-		"]|恒河沙|阿僧祇|那由他|不可思議|無量大数)+"
-)
-
-var (
-	regexpInt    = regexp.MustCompile(patternInt)
-	regexpBigInt = regexp.MustCompile(patternBigInt)
 )
 
 // SearchResult is single match in a text that may be a japanese numeral.
@@ -253,24 +341,9 @@ type SearchResult struct {
 	Str        string
 	Value      uint64
 	Err        error
-}
-
-// Find returns an array of all potential japanese numerals in the given string.
-func Find(s string) []*SearchResult {
-	if len(s) < utf8KanjiBytes {
-		return []*SearchResult{}
-	}
-	results := make([]*SearchResult, 0)
-	for _, match := range regexpInt.FindAllStringIndex(s, -1) {
-		result := &SearchResult{
-			Start: match[0],
-			End:   match[1],
-			Str:   s[match[0]:match[1]],
-		}
-		result.Value, result.Err = ParseUint(result.Str)
-		results = append(results, result)
-	}
-	return results
+	// Negative is true if Signs.Find found a negative sign in front of the numeral.
+	// Value is the absolute value in this case.
+	Negative bool
 }
 
 // SearchBigIntResult is single match in a text that may be a japanese numeral.
@@ -279,22 +352,7 @@ type SearchBigIntResult struct {
 	Str        string
 	Value      *big.Int
 	Err        error
-}
-
-// FindBigInt returns an array of all potential japanese numerals in the given string.
-func FindBigInt(s string) []*SearchBigIntResult {
-	if len(s) < utf8KanjiBytes {
-		return []*SearchBigIntResult{}
-	}
-	results := make([]*SearchBigIntResult, 0)
-	for _, match := range regexpBigInt.FindAllStringIndex(s, -1) {
-		result := &SearchBigIntResult{
-			Start: match[0],
-			End:   match[1],
-			Str:   s[match[0]:match[1]],
-		}
-		result.Value, result.Err = ParseBigInt(result.Str)
-		results = append(results, result)
-	}
-	return results
+	// Negative is true if Signs.FindBigInt found a negative sign in front of the
+	// numeral. Value is the absolute value in this case.
+	Negative bool
 }
@@ -2,8 +2,10 @@ package jnumber
 
 import (
 	"errors"
+	"math/big"
 	"math/rand"
 	"testing"
+	"unicode/utf8"
 )
 
 var uint64Kanjis = [...]rune{
@@ -52,6 +54,25 @@ func TestFormatParseUintRandom(t *testing.T) {
 	}
 }
 
+func TestFormatParseBigIntUnboundedRandom(t *testing.T) {
+	var limit big.Int
+	limit.Exp(big.NewInt(10), big.NewInt(300), nil)
+	for i := 0; i < 10_000; i++ {
+		var expected big.Int
+		expected.Rand(rand.New(rand.NewSource(int64(i))), &limit)
+		str := FormatBigIntUnbounded(&expected)
+		actual, err := ParseBigInt(str)
+		if err != nil {
+			t.Errorf("err: %v", err)
+			t.FailNow()
+		}
+		if actual.Cmp(&expected) != 0 {
+			t.Errorf("expected: %s, actual: %s, str: %s", &expected, actual, str)
+			t.FailNow()
+		}
+	}
+}
+
 func TestValueOf(t *testing.T) {
 	for _, k := range uint64Kanjis {
 		t.Run(string(k), func(t *testing.T) {
@@ -123,6 +144,15 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestFindBigIntSerial(t *testing.T) {
+	actual := FindBigInt("番号一二三四五六七八九〇一二三四五六七八九〇一、二〇二三年と三百十十")
+	expectEqual(t, 3, len(actual))
+	expectEqual(t, "123456789012345678901", actual[0].Value.String())
+	expectEqual(t, "2023", actual[1].Value.String())
+	expectEqual(t, "三百十十", actual[2].Str)
+	expectErrIs(t, ErrInvalidSequence, actual[2].Err)
+}
+
 func BenchmarkValueOf(b *testing.B) {
 	for _, k := range uint64Kanjis {
 		b.Run(string(k), func(b *testing.B) {
@@ -225,3 +255,57 @@ func valueOfSwitch(r rune) (value uint64, ok bool) {
 		return 0, false
 	}
 }
+
+var parseErrorCases = []struct {
+	Func     string
+	Parse    func(string) error
+	Input    string
+	Offset   int
+	Rune     rune
+	Expected error
+}{
+	{"ParseInt", func(s string) error { _, err := ParseInt(s); return err }, "", 0, 0, ErrEmpty},
+	{"ParseInt", func(s string) error { _, err := ParseInt(s); return err }, "二十a", 6, 'a', ErrUnexpectedRune},
+	{"ParseInt", func(s string) error { _, err := ParseInt(s); return err }, negativePrefix + "一一", 15, '一', ErrInvalidSequence},
+	{"ParseUint", func(s string) error { _, err := ParseUint(s); return err }, "十百", 3, '百', ErrInvalidSequence},
+	{"ParseUint", func(s string) error { _, err := ParseUint(s); return err }, "一垓", 3, '垓', ErrOverflow},
+	{"ParseUint", func(s string) error { _, err := ParseUint(s); return err }, "〇垓", 3, '垓', ErrUnexpectedRune},
+	{"ParseUint", func(s string) error { _, err := ParseUint(s); return err }, "一" + string(utf8.RuneError), 3, utf8.RuneError, ErrEncoding},
+	{"ParseSerialInt", func(s string) error { _, err := ParseSerialInt(s); return err }, negativePrefix + "一十", 15, '十', ErrInvalidSequence},
+	{"ParseSerialUint", func(s string) error { _, err := ParseSerialUint(s); return err }, "一二三x", 9, 'x', ErrUnexpectedRune},
+	{"ParseBigInt", func(s string) error { _, err := ParseBigInt(s); return err }, "一恒河一", 9, '一', &UnexpectedRuneError{'一', '沙'}},
+	{"ParseBigInt", func(s string) error { _, err := ParseBigInt(s); return err }, "一恒河", 9, 0, ErrEOF},
+	{"ParseBigInt", func(s string) error { _, err := ParseBigInt(s); return err }, negativePrefix + "一垓二垓", 21, '垓', ErrInvalidSequence},
+	{"ParseHistoricalUint", func(s string) error { _, err := ParseHistoricalUint(s); return err }, "廿一一", 6, '一', ErrInvalidSequence},
+	{"ParseHistoricalUint", func(s string) error { _, err := ParseHistoricalUint(s); return err }, "廿有", 3, '有', ErrInvalidSequence},
+	{"ParseBigIntUnits", func(s string) error { _, err := ParseBigIntUnits(s, Decimal); return err }, "一万一億", 9, '億', ErrInvalidSequence},
+	{"ParseChineseInt", func(s string) error { _, err := ParseChineseInt(s); return err }, "负两十", 3, '两', ErrInvalidSequence},
+	{"ParseChineseUint", func(s string) error { _, err := ParseChineseUint(s); return err }, "一千零五x", 12, 'x', ErrUnexpectedRune},
+	{"ParseKoreanUint", func(s string) error { _, err := ParseKoreanUint(s); return err }, "삼만  오천", 7, ' ', ErrUnexpectedRune},
+	{"ParseSuzhouUint", func(s string) error { _, err := ParseSuzhouUint(s); return err }, "〡〢十", 6, '十', ErrUnexpectedRune},
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range parseErrorCases {
+		t.Run(tc.Func+"/"+tc.Input, func(t *testing.T) {
+			err := tc.Parse(tc.Input)
+			expectErrIs(t, tc.Expected, err)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected ParseError, actual: %T", err)
+			}
+			expectEqual(t, tc.Func, parseErr.Func)
+			expectEqual(t, tc.Input, parseErr.Input)
+			expectEqual(t, tc.Offset, parseErr.Offset)
+			expectEqual(t, utf8.RuneCountInString(tc.Input[:tc.Offset]), parseErr.RuneOffset)
+			expectEqual(t, tc.Rune, parseErr.Rune)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := ParseUint("十百")
+	expectEqual(t, `jnumber.ParseUint: parsing "十百": invalid sequence of digits at offset 3`, err.Error())
+	_, err = ParseUint("")
+	expectEqual(t, `jnumber.ParseUint: parsing "": empty string`, err.Error())
+}
@@ -8,174 +8,240 @@ import (
 )
 
 // ParseInt returns the integer represented by the given japanese numerals.
+// Negative numbers must start with マイナス, use Signs.ParseInt for other signs.
 func ParseInt(s string) (int64, error) {
+	i, err := parseInt(s, nil)
+	if err != nil {
+		return 0, newParseError("ParseInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseUint returns the unsigned integer represented by the given japanese numerals.
+func ParseUint(s string) (uint64, error) {
+	u, err := parseUint(s, nil)
+	if err != nil {
+		return 0, newParseError("ParseUint", s, err)
+	}
+	return u, nil
+}
+
+// parseInt parses the numerals with the runes of the given alphabet or with the
+// default runes if alphabet is nil.
+func parseInt(s string, alphabet *Alphabet) (int64, error) {
 	abs := strings.TrimPrefix(s, negativePrefix)
 	isNegative := s != abs
-	sum, err := ParseUint(abs)
+	sum, err := parseUint(abs, alphabet)
 	if err != nil {
+		return 0, shiftError(err, len(s)-len(abs))
+	}
+	return toInt64(sum, isNegative)
+}
+
+// parseUint parses the numerals with the runes of the given alphabet or with the
+// default runes if alphabet is nil.
+func parseUint(s string, alphabet *Alphabet) (uint64, error) {
+	n := len(s)
+	if n == 0 {
+		return 0, ErrEmpty
+	}
+	sum, i, err := scanUint(s, alphabet)
+	if _, ok := err.(*ParseError); ok {
 		return 0, err
 	}
-	if isNegative {
-		if sum > -math.MinInt64 {
-			return 0, ErrOverflow
+	// are there still runes in the string after we are done?
+	if i < n {
+		// a unit after a leading zero is not an overflow, because the zero must be
+		// the only rune
+		if i < n-2 && err != ErrInvalidSequence {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			switch r {
+			// 10^20 - 10^68 overflows uint64
+			// only the first kanji for multi kanji numbers
+			case '垓', '秭', '𥝱', '穣', '溝',
+				'澗', '正', '載', '極',
+				'恒', '阿', '那', '不',
+				'無':
+				return 0, errorAt(i, ErrOverflow)
+			}
 		}
-		return -int64(sum), nil
+		return 0, errorAt(i, checkUnexpectedRune(s[i:]))
 	}
-	if sum > math.MaxInt64 {
-		return 0, ErrOverflow
+	if err != nil {
+		return 0, err
 	}
-	return int64(sum), nil
+	return sum, nil
 }
 
-// ParseUint returns the unsigned integer represented by the given japanese numerals.
-func ParseUint(s string) (uint64, error) {
+// scanUint parses the numerals at the start of s up to the first rune without a
+// value and returns their sum and end. Invalid sequences are returned as ParseError
+// at the offending rune, an overflow of the sum of the last segment as ErrOverflow
+// and a leading zero that is followed by more runes as ErrInvalidSequence at the
+// end of the zero.
+func scanUint(s string, alphabet *Alphabet) (uint64, int, error) {
 	n := len(s)
-	if n == 0 {
-		return 0, ErrEmpty
-	}
 	sum := uint64(0)
-	segment := uint64(0)
-	lastValue := uint64(0)
-	minSegmentValue := uint64(math.MaxUint64)
+	var seg segment
 	minSegmentEnd := uint64(math.MaxUint64)
 	i := 0
+	size := utf8KanjiBytes
 loop:
-	for ; i < n-2; i += utf8KanjiBytes {
+	for ; i < n-2; i += size {
 		r := decodeUtf8Kanji(i, s)
-		value, ok := ValueOf(r)
+		size = utf8KanjiBytes
+		if s[i] >= utf8FourBytesLead {
+			r, size = utf8.DecodeRuneInString(s[i:])
+		}
+		value, ok := alphabet.ValueOf(r)
 		if value > 0 && ok {
-			if value < 10 { // 1 to 9
-				// last number must not be < 10 as well
-				if 0 < lastValue && lastValue < 10 {
-					return 0, ErrInvalidSequence
+			if value < i万 {
+				if !seg.push(value) {
+					return 0, i, errorAt(i, ErrInvalidSequence)
 				}
-				lastValue = value
-			} else if value < 10_000 { // 10, 100, 1000
-				// check if we already encountered this number in the current segment
-				if value >= minSegmentValue {
-					return 0, ErrInvalidSequence
-				}
-				minSegmentValue = value
-				// multiply with last number if allowed and possible
-				if 0 < lastValue && lastValue < 10 {
-					segment += lastValue * value
-				} else {
-					segment += value
-				}
-				lastValue = value
-			} else { // >= 1_0000
-				// check if we already encountered this number
-				if value >= minSegmentEnd {
-					return 0, ErrInvalidSequence
-				}
-				minSegmentEnd = value
-				// create sum of current segment and add to sum
-				if 0 < lastValue && lastValue < 10 {
-					segment += lastValue
-				}
-				if segment == 0 {
-					return 0, ErrInvalidSequence
-				}
-
-				var carry uint64
-				overflow, segmentSum := bits.Mul64(segment, value)
-				sum, carry = bits.Add64(sum, segmentSum, 0)
-				if carry > 0 || overflow > 0 {
-					return 0, ErrOverflow
-				}
-				// prepare for new segment
-				minSegmentValue = uint64(math.MaxUint64)
-				segment = 0
-				lastValue = 0
+				continue
 			}
-		} else {
-			switch r {
-			case '零', '〇':
-				// zero is only valid if it is the only rune
-				if i == 0 {
-					i += utf8KanjiBytes
-					break loop
+			// >= 1_0000: check if we already encountered this number and if there
+			// is a multiplier
+			multiplier := seg.value()
+			if value >= minSegmentEnd || multiplier == 0 {
+				return 0, i, errorAt(i, ErrInvalidSequence)
+			}
+			minSegmentEnd = value
+			// add the product of the segment and the unit to sum
+			var carry uint64
+			overflow, segmentSum := bits.Mul64(multiplier, value)
+			sum, carry = bits.Add64(sum, segmentSum, 0)
+			if carry > 0 || overflow > 0 {
+				return 0, i, errorAt(i, ErrOverflow)
+			}
+			seg = segment{}
+		} else if ok {
+			// zero is only valid if it is the only rune
+			if i == 0 {
+				if size == n {
+					return 0, n, nil
 				}
-				return 0, ErrInvalidSequence
-			// 10^20 - 10^68 overflows uint64
-			// only the first kanji for multi kanji numbers
-			case '垓', '秭', '穣', '溝',
-				'澗', '正', '載', '極',
-				'恒', '阿', '那', '不',
-				'無':
-				return 0, ErrOverflow
-			default:
-				return 0, checkUnexpectedRune(s[i:])
+				return 0, size, ErrInvalidSequence
 			}
+			return 0, i, errorAt(i, ErrInvalidSequence)
+		} else {
+			break loop
 		}
 	}
-	// are there still runes in the string after we are done?
-	if i < n {
-		return 0, checkUnexpectedRune(s[i:])
-	}
 	// add last segment to sum if there is one
-	if 0 < lastValue && lastValue < 10 {
-		segment += lastValue
-	}
-	if segment > 0 {
-		var carry uint64
-		sum, carry = bits.Add64(sum, segment, 0)
-		if carry > 0 {
-			return 0, ErrOverflow
-		}
+	var carry uint64
+	sum, carry = bits.Add64(sum, seg.value(), 0)
+	if carry > 0 {
+		return 0, i, ErrOverflow
 	}
-	return sum, nil
+	return sum, i, nil
 }
 
 // ParseSerialInt returns the signed integer represented by the given japanese numerals.
+// Negative numbers must start with マイナス, use Signs.ParseSerialInt for other signs.
 func ParseSerialInt(s string) (int64, error) {
+	i, err := parseSerialInt(s)
+	if err != nil {
+		return 0, newParseError("ParseSerialInt", s, err)
+	}
+	return i, nil
+}
+
+func parseSerialInt(s string) (int64, error) {
 	abs := strings.TrimPrefix(s, negativePrefix)
 	isNegative := s != abs
-	sum, err := ParseSerialUint(abs)
+	sum, err := parseSerialUint(abs)
 	if err != nil {
-		return 0, err
-	}
-	if isNegative {
-		if sum > -math.MinInt64 {
-			return 0, ErrOverflow
-		}
-		return -int64(sum), nil
-	}
-	if sum > math.MaxInt64 {
-		return 0, ErrOverflow
+		return 0, shiftError(err, len(s)-len(abs))
 	}
-	return int64(sum), nil
+	return toInt64(sum, isNegative)
 }
 
 // ParseSerialUint returns the unsigned integer represented by the given japanese numerals.
 func ParseSerialUint(s string) (uint64, error) {
+	u, err := parseSerialUint(s)
+	if err != nil {
+		return 0, newParseError("ParseSerialUint", s, err)
+	}
+	return u, nil
+}
+
+func parseSerialUint(s string) (uint64, error) {
 	n := len(s)
 	if n == 0 {
 		return 0, ErrEmpty
 	}
 	sum := uint64(0)
 	i := 0
-	for ; i < n-2; i += utf8KanjiBytes {
+	for size := utf8KanjiBytes; i < n-2; i += size {
 		r := decodeUtf8Kanji(i, s)
+		size = utf8KanjiBytes
+		if s[i] >= utf8FourBytesLead {
+			r, size = utf8.DecodeRuneInString(s[i:])
+		}
 		value, ok := ValueOf(r)
 		if value < 10 && ok {
 			var carry uint64
 			overflow, tmpSum := bits.Mul64(sum, 10)
 			sum, carry = bits.Add64(tmpSum, value, 0)
 			if carry > 0 || overflow > 0 {
-				return 0, ErrOverflow
+				return 0, errorAt(i, ErrOverflow)
 			}
 		} else {
-			return 0, ErrInvalidSequence
+			return 0, errorAt(i, ErrInvalidSequence)
 		}
 	}
 	// are there still runes in the string after we are done?
 	if i < n {
-		return 0, checkUnexpectedRune(s[i:])
+		return 0, errorAt(i, checkUnexpectedRune(s[i:]))
 	}
 	return sum, nil
 }
 
+// ParseSerialIntWidth returns the signed integer represented by the given serial
+// japanese numerals and the number of digits including leading zeros, so that
+// AppendSerialIntWidth restores the original string.
+func ParseSerialIntWidth(s string) (int64, int, error) {
+	abs := strings.TrimPrefix(s, negativePrefix)
+	i, err := parseSerialInt(s)
+	if err != nil {
+		return 0, 0, newParseError("ParseSerialIntWidth", s, err)
+	}
+	return i, serialWidth(abs), nil
+}
+
+// ParseSerialUintWidth returns the unsigned integer represented by the given serial
+// japanese numerals and the number of digits including leading zeros, so that
+// AppendSerialUintWidth restores the original string.
+func ParseSerialUintWidth(s string) (uint64, int, error) {
+	u, err := parseSerialUint(s)
+	if err != nil {
+		return 0, 0, newParseError("ParseSerialUintWidth", s, err)
+	}
+	return u, serialWidth(s), nil
+}
+
+// serialWidth returns the number of digits of valid serial numerals. All digits
+// consist of 3 bytes.
+func serialWidth(s string) int {
+	return len(s) / utf8KanjiBytes
+}
+
+// toInt64 returns the absolute value with the given sign or ErrOverflow if the
+// result does not fit into int64.
+func toInt64(abs uint64, isNegative bool) (int64, error) {
+	if isNegative {
+		if abs > -math.MinInt64 {
+			return 0, ErrOverflow
+		}
+		return -int64(abs), nil
+	}
+	if abs > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(abs), nil
+}
+
 // if our custom decoding fails, use the correct implementation from the standard library.
 func checkUnexpectedRune(s string) error {
 	r, _ := utf8.DecodeRuneInString(s)
@@ -187,6 +253,12 @@ func checkUnexpectedRune(s string) error {
 	}
 }
 
+// utf8FourBytesLead is the smallest first byte of a 4 byte rune. The rare numerals
+// of the supplementary planes like 𥝱 are encoded with 4 bytes and decoded with
+// utf8.DecodeRuneInString instead of decodeUtf8Kanji. The check is done inline to
+// keep the fast path for 3 byte kanji as fast as before.
+const utf8FourBytesLead = 0b_1111_0000
+
 // All kanji we want consist of 3 bytes in utf-8 encoding. This may seem unsafe,
 // but if we encounter an unexpected or invalid rune, ValueOf will catch those
 // values and we can retrieve the real rune with utf8.DecodeRuneInString and
@@ -0,0 +1,209 @@
+package jnumber
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind describes how a number was written.
+type Kind int
+
+const (
+	// KindPositional are numerals with units like FormatUint: 二千二十三
+	KindPositional Kind = iota
+	// KindSerial are digits without units like FormatSerialUint: 二〇二三
+	KindSerial
+	// KindDaiji are numerals with units and at least one daiji: 弐千弐拾参
+	KindDaiji
+	// KindArabic are only arabic digits: 2023 or ２０２３
+	KindArabic
+	// KindMixedArabic are arabic digits combined with kanji units: 2万5000
+	KindMixedArabic
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindPositional:
+		return "positional"
+	case KindSerial:
+		return "serial"
+	case KindDaiji:
+		return "daiji"
+	case KindArabic:
+		return "arabic"
+	case KindMixedArabic:
+		return "mixed arabic"
+	default:
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// ParseAny returns the integer represented by the given numerals and the Kind of
+// the numerals. See ParseAnyBigInt for the detection of the kind.
+func ParseAny(s string) (int64, Kind, error) {
+	i, kind, err := parseAnyBigInt(s)
+	if err == nil && !i.IsInt64() {
+		err = ErrOverflow
+	}
+	if err != nil {
+		return 0, kind, newParseError("ParseAny", s, err)
+	}
+	return i.Int64(), kind, nil
+}
+
+// ParseAnyBigInt returns the integer represented by the given numerals and the
+// Kind of the numerals. Numerals with arabic digits (ASCII or fullwidth) are
+// KindArabic or KindMixedArabic, e.g. 2万5000, and each group of arabic digits
+// stands for the same kanji, so 2千500 is 2500. Several digits without units and
+// 〇 on its own are KindSerial. All other numerals are KindPositional or KindDaiji.
+// A single digit like 一 is KindPositional, because FormatUint and FormatSerialUint
+// produce the same string. マイナス is accepted as a negative prefix by all kinds.
+func ParseAnyBigInt(s string) (*big.Int, Kind, error) {
+	i, kind, err := parseAnyBigInt(s)
+	if err != nil {
+		return nil, kind, newParseError("ParseAnyBigInt", s, err)
+	}
+	return i, kind, nil
+}
+
+func parseAnyBigInt(s string) (*big.Int, Kind, error) {
+	abs := strings.TrimPrefix(s, negativePrefix)
+	shift := len(s) - len(abs)
+	kind := detectKind(abs)
+	var i *big.Int
+	var err error
+	switch kind {
+	case KindSerial, KindArabic:
+		i, err = parseDigits(abs)
+	case KindMixedArabic:
+		i, err = parseMixedArabic(abs)
+	default:
+		i, err = parseBigInt(abs, nil)
+	}
+	if err != nil {
+		return nil, kind, shiftError(err, shift)
+	}
+	if s != abs {
+		i.Neg(i)
+	}
+	return i, kind, nil
+}
+
+// detectKind returns the Kind of the numerals without a sign. Invalid numerals are
+// reported as KindPositional.
+func detectKind(s string) Kind {
+	hasArabic, hasKanji, hasUnit, hasDaiji := false, false, false, false
+	for _, r := range s {
+		if _, ok := arabicDigit(r); ok {
+			hasArabic = true
+			continue
+		}
+		hasKanji = true
+		if value, ok := ValueOf(r); !ok || value >= i十 {
+			hasUnit = true
+		}
+		if strings.ContainsRune(daijiRunes+obsoletDajiRunes, r) {
+			hasDaiji = true
+		}
+	}
+	switch {
+	case hasArabic && hasKanji:
+		return KindMixedArabic
+	case hasArabic:
+		return KindArabic
+	case !hasUnit && (utf8.RuneCountInString(s) > 1 || s == "〇"):
+		return KindSerial
+	case hasDaiji:
+		return KindDaiji
+	default:
+		return KindPositional
+	}
+}
+
+// arabicDigit returns the value of an ASCII or fullwidth digit.
+func arabicDigit(r rune) (byte, bool) {
+	switch {
+	case '0' <= r && r <= '9':
+		return byte(r - '0'), true
+	case '０' <= r && r <= '９':
+		return byte(r - '０'), true
+	default:
+		return 0, false
+	}
+}
+
+// parseDigits returns the value of serial or arabic digits.
+func parseDigits(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, ErrEmpty
+	}
+	digits := make([]byte, 0, len(s))
+	for i, r := range s {
+		digit, ok := arabicDigit(r)
+		if !ok {
+			value, ok := ValueOf(r)
+			if !ok || value >= 10 {
+				return nil, errorAt(i, checkUnexpectedRune(s[i:]))
+			}
+			digit = byte(value)
+		}
+		digits = append(digits, '0'+digit)
+	}
+	result, _ := new(big.Int).SetString(string(digits), 10)
+	return result, nil
+}
+
+// parseMixedArabic replaces each group of arabic digits with japanese numerals and
+// parses the result.
+func parseMixedArabic(s string) (*big.Int, error) {
+	var b strings.Builder
+	// spans contains the start in b and in s for each rune or digit group
+	type span struct{ normalized, original int }
+	var spans []span
+	for i := 0; i < len(s); {
+		spans = append(spans, span{b.Len(), i})
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if _, ok := arabicDigit(r); !ok {
+			b.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+		end := i
+		for end < len(s) {
+			r, size := utf8.DecodeRuneInString(s[end:])
+			if _, ok := arabicDigit(r); !ok {
+				break
+			}
+			end += size
+		}
+		group, _ := parseDigits(s[i:end])
+		if group.Sign() == 0 && i > 0 && end == len(s) {
+			// Example: 1万0 -> 一万 instead of the invalid 一万零
+			last, _ := utf8.DecodeLastRuneInString(s[:i])
+			if value, ok := ValueOf(last); !ok || value >= i十 {
+				i = end
+				continue
+			}
+		}
+		b.WriteString(FormatBigIntUnbounded(group))
+		i = end
+	}
+	result, err := parseBigInt(b.String(), nil)
+	if e, ok := err.(*ParseError); ok {
+		// move the error to the start of the corresponding rune or digit group
+		original := len(s)
+		for _, sp := range spans {
+			if sp.normalized > e.Offset {
+				break
+			}
+			original = sp.original
+		}
+		if e.Offset >= b.Len() {
+			original = len(s)
+		}
+		e.Offset = original
+	}
+	return result, err
+}
@@ -0,0 +1,95 @@
+package jnumber
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	for _, tc := range []struct {
+		Input    string
+		Expected int64
+		Kind     Kind
+	}{
+		{"二千二十三", 2023, KindPositional},
+		{"二〇二三", 2023, KindSerial},
+		{"二二", 22, KindSerial},
+		{"一〇", 10, KindSerial},
+		{"〇", 0, KindSerial},
+		{"零", 0, KindPositional},
+		{"一", 1, KindPositional},
+		{"十", 10, KindPositional},
+		{"一万", 10_000, KindPositional},
+		{"弐千弐拾参", 2023, KindDaiji},
+		{"壹萬", 10_000, KindDaiji},
+		{"2023", 2023, KindArabic},
+		{"２０２３", 2023, KindArabic},
+		{"2万5000", 25_000, KindMixedArabic},
+		{"2千500", 2500, KindMixedArabic},
+		{"1万0", 10_000, KindMixedArabic},
+		{"1億2345万6789", 123_456_789, KindMixedArabic},
+		{"１２億", 1_200_000_000, KindMixedArabic},
+		{"3万五千", 35_000, KindMixedArabic},
+		{"マイナス二〇二三", -2023, KindSerial},
+		{"マイナス十", -10, KindPositional},
+		{"マイナス5万", -50_000, KindMixedArabic},
+		{"九百二十二京三千三百七十二兆三百六十八億五千四百七十七万五千八百七", math.MaxInt64, KindPositional},
+	} {
+		actual, kind, err := ParseAny(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		expectEqual(t, tc.Kind, kind)
+		actualBigInt, kind, err := ParseAnyBigInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actualBigInt.Int64())
+		expectEqual(t, tc.Kind, kind)
+	}
+}
+
+func TestParseAnyBigInt(t *testing.T) {
+	for _, tc := range []struct {
+		Input    string
+		Expected string
+		Kind     Kind
+	}{
+		{"一垓", "100000000000000000000", KindPositional},
+		{"一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇", "100000000000000000000", KindSerial},
+		{"100000000000000000000", "100000000000000000000", KindArabic},
+		{"1垓", "100000000000000000000", KindMixedArabic},
+	} {
+		actual, kind, err := ParseAnyBigInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual.String())
+		expectEqual(t, tc.Kind, kind)
+	}
+}
+
+func TestParseAnyError(t *testing.T) {
+	for _, tc := range []struct {
+		Input    string
+		Offset   int
+		Expected error
+	}{
+		{"", 0, ErrEmpty},
+		{"マイナス", 12, ErrEmpty},
+		{"百十百", 6, ErrInvalidSequence},
+		{"2万3万", 5, ErrInvalidSequence},
+		{"2万a", 4, ErrUnexpectedRune},
+		{"十a", 3, ErrUnexpectedRune},
+		{"1垓", 4, ErrOverflow},
+	} {
+		_, _, err := ParseAny(tc.Input)
+		expectErrIs(t, tc.Expected, err)
+		var parseErr *ParseError
+		expectEqual(t, true, errors.As(err, &parseErr))
+		expectEqual(t, "ParseAny", parseErr.Func)
+		expectEqual(t, tc.Offset, parseErr.Offset)
+	}
+}
+
+func TestKindString(t *testing.T) {
+	expectEqual(t, "serial", KindSerial.String())
+	expectEqual(t, "mixed arabic", KindMixedArabic.String())
+	expectEqual(t, "Kind(9)", Kind(9).String())
+}
@@ -1,287 +1,624 @@
 package jnumber
 
 import (
+	"errors"
 	"math/big"
+	"math/bits"
 	"strings"
+	"unicode/utf8"
 )
 
 // ParseBigInt returns the integer represented by the given japanese numerals.
+// A repeated 無量大数 multiplies everything in front of it, which allows numbers
+// >= 10^72 like 一万無量大数 (10^72) or 一無量大数無量大数 (10^136). Negative
+// numbers must start with マイナス, use Signs.ParseBigInt for other signs.
 func ParseBigInt(s string) (*big.Int, error) {
+	i, err := parseBigInt(s, nil)
+	if err != nil {
+		return nil, newParseError("ParseBigInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseBigIntInto sets dst to the integer represented by the given japanese numerals
+// like ParseBigInt and returns dst. The memory of dst is reused, which avoids
+// allocations if dst is reused for many calls. The value of dst is undefined if an
+// error is returned, like for big.Int.SetString.
+func ParseBigIntInto(dst *big.Int, s string) (*big.Int, error) {
+	i, err := parseBigIntInto(dst, s, nil)
+	if err != nil {
+		return nil, newParseError("ParseBigIntInto", s, err)
+	}
+	return i, nil
+}
+
+// parseBigInt parses the numerals with the runes of the given alphabet or with the
+// default runes if alphabet is nil.
+func parseBigInt(s string, alphabet *Alphabet) (*big.Int, error) {
+	return parseBigIntInto(new(big.Int), s, alphabet)
+}
+
+// parseBigIntInto is parseBigInt with the result stored in dst.
+func parseBigIntInto(dst *big.Int, s string, alphabet *Alphabet) (*big.Int, error) {
 	if s == "" {
 		return nil, ErrEmpty
 	}
 	initBigIntsOnce.Do(initBigInts)
 	abs := strings.TrimPrefix(s, negativePrefix)
 	isNegative := s != abs
-	parser := bigIntParser{
-		sum: big.NewInt(0),
+	// fast path for numbers < 2^128, the errors are reported by bigIntParser
+	if u, _, err := scanUint128(abs, alphabet); err == nil {
+		if isNegative {
+			return setUint128(dst, u).Neg(dst), nil
+		}
+		return setUint128(dst, u), nil
 	}
+	parser := newBigIntParser(dst.SetInt64(0), &japaneseRules, alphabet)
 	err := parser.parse(abs)
 	if err != nil {
-		return nil, err
+		return nil, shiftError(err, len(s)-len(abs))
 	}
 	if isNegative {
-		return parser.sum.Neg(parser.sum), nil
+		return dst.Neg(parser.bigInt()), nil
 	}
-	return parser.sum, nil
+	return parser.bigInt(), nil
 }
 
-// parser contains the state for the parsing process. The segment could be a slice
-// for easier code but this way we can avoid an allocation.
-type bigIntParser struct {
-	// current sum
-	sum *big.Int
-	// min digit >= 一万 that ends a segment
-	minSegmentEndDigit *big.Int
-	// min digit >= 十 of the current segment
-	minSegmentDigit *big.Int
-	// holds digit until we know what to do with them
-	segment [16]big.Int
-	// position in the segment array for the next digit
-	segmentIndex int
+// ParseSerialBigInt returns the integer represented by the given serial japanese
+// numerals like ParseSerialInt, but without a limit for the number of digits.
+func ParseSerialBigInt(s string) (*big.Int, error) {
+	i, err := parseSerialBigInt(s)
+	if err != nil {
+		return nil, newParseError("ParseSerialBigInt", s, err)
+	}
+	return i, nil
+}
+
+func parseSerialBigInt(s string) (*big.Int, error) {
+	abs := strings.TrimPrefix(s, negativePrefix)
+	isNegative := s != abs
+	result, err := parseSerialBigUint(abs)
+	if err != nil {
+		return nil, shiftError(err, len(s)-len(abs))
+	}
+	if isNegative {
+		result.Neg(result)
+	}
+	return result, nil
 }
 
-// append adds a digit to the current segment.
-func (p *bigIntParser) append(digit *big.Int) {
-	p.segment[p.segmentIndex].Set(digit)
-	p.segmentIndex++
+// parseSerialBigUint uses parseSerialUint for numbers that fit into uint64 and
+// collects the digits of bigger numbers for big.Int.SetString.
+func parseSerialBigUint(s string) (*big.Int, error) {
+	u, err := parseSerialUint(s)
+	if err == nil {
+		return new(big.Int).SetUint64(u), nil
+	} else if !errors.Is(err, ErrOverflow) {
+		return nil, err
+	}
+	n := len(s)
+	digits := make([]byte, 0, n/utf8KanjiBytes)
+	i := 0
+	for size := utf8KanjiBytes; i < n-2; i += size {
+		r := decodeUtf8Kanji(i, s)
+		size = utf8KanjiBytes
+		if s[i] >= utf8FourBytesLead {
+			r, size = utf8.DecodeRuneInString(s[i:])
+		}
+		value, ok := ValueOf(r)
+		if value >= 10 || !ok {
+			return nil, errorAt(i, ErrInvalidSequence)
+		}
+		digits = append(digits, '0'+byte(value))
+	}
+	if i < n {
+		return nil, errorAt(i, checkUnexpectedRune(s[i:]))
+	}
+	result, _ := new(big.Int).SetString(string(digits), 10)
+	return result, nil
 }
 
-// clearSegment clears the current segment to start parsing a new segment.
-func (p *bigIntParser) clearSegment() {
-	p.segmentIndex = 0
-	p.minSegmentDigit = nil
+// numeralRules contains the rules of bigIntParser that differ between numeral
+// systems. The zero value parses japanese numerals in the 万進 system up to the
+// limit of FormatBigInt.
+type numeralRules struct {
+	// System defines the values of the units >= 万.
+	System UnitSystem
+	// ZeroPlaceholders allows a zero between two parts of a number if at least
+	// one unit is skipped, e.g. 一千零五 for 1005.
+	ZeroPlaceholders bool
+	// ImplicitNextUnit multiplies a single digit at the end of the number with the
+	// next lower unit of the unit in front of it, e.g. 一千五 for 1500.
+	ImplicitNextUnit bool
+	// ImplicitOne is the number of units >= 万 that do not need a multiplier, e.g.
+	// 1 for 만 (10^4) in Korean.
+	ImplicitOne int
+	// LargeHundredMillion allows multipliers with 万 for 億, e.g. 一万亿 for 10^12.
+	LargeHundredMillion bool
+	// UnboundedBiggestUnit allows a repeated biggest unit, which multiplies
+	// everything in front of it, e.g. 一無量大数無量大数 for 10^136 like ParseBigInt.
+	// Otherwise a multiplier with more digits than UnitSystem.multiplierDigits
+	// returns ErrOverflow like AppendBigIntUnits.
+	UnboundedBiggestUnit bool
 }
 
-// push integrates a new digit to the current segment.
-func (p *bigIntParser) push(digit *big.Int) error {
-	if p.segmentIndex == 0 {
-		p.append(digit)
-		return nil
-	} else if digit.Cmp(&b十) >= 0 {
-		if p.minSegmentDigit != nil && digit.Cmp(p.minSegmentDigit) >= 0 {
+// japaneseRules are the rules of ParseBigInt.
+var japaneseRules = numeralRules{UnboundedBiggestUnit: true}
+
+// multiplierLimit returns the maximum number of digits of the multiplier of the
+// k-th unit >= 万.
+func (rules *numeralRules) multiplierLimit(k int) int {
+	if k == 1 && rules.LargeHundredMillion {
+		return rules.System.multiplierDigits(0) + rules.System.multiplierDigits(1)
+	}
+	return rules.System.multiplierDigits(k)
+}
+
+// unitMultiplier is a unit >= 万 and its multiplier.
+type unitMultiplier struct {
+	// index in myriadUnits
+	unit int
+	// the multiplier has at most numeralRules.multiplierLimit digits
+	multiplier uint64
+}
+
+// bigIntParser contains the state for the parsing process. Every unit >= 万 ends a
+// segment, which becomes the multiplier of the unit. A unit that is bigger than the
+// units in front of it multiplies them too, e.g. 一万億 (10^12) in the 万万進 system,
+// so all multipliers fit into uint64 and only the result needs big.Int.
+type bigIntParser struct {
+	rules *numeralRules
+	// the units that ended a segment in descending order
+	units [len(myriadUnits)]unitMultiplier
+	count int
+	// product of a repeated biggest unit with everything in front of it, may be nil
+	// if the biggest unit is not repeated
+	sum     *big.Int
+	product big.Int
+	// index in myriadUnits of the unit that ended the last segment or -1
+	lastUnit int
+	// the numerals < 万 of the current segment
+	seg segment
+	// exponent of the unit in front of a zero placeholder in the current segment
+	// or -1 and the offset of the zero
+	segmentZero, segmentZeroOffset int
+	// exponent of the last digit in front of a zero placeholder after a unit >= 万
+	// or -1 and the offset of the zero
+	unitZero, unitZeroOffset int
+	// the rules ZeroPlaceholder and ImplicitNextUnit, if they have been applied
+	applied LenientRule
+	// additional runes, may be nil
+	alphabet *Alphabet
+}
+
+// newBigIntParser returns a parser for the given rules. The result is stored in
+// sum, which may be nil for results that are converted with uint64.
+func newBigIntParser(sum *big.Int, rules *numeralRules, alphabet *Alphabet) bigIntParser {
+	return bigIntParser{
+		rules:       rules,
+		sum:         sum,
+		lastUnit:    -1,
+		segmentZero: -1,
+		unitZero:    -1,
+		alphabet:    alphabet,
+	}
+}
+
+// push adds a digit 1 to 9 or one of the units 十, 百 and 千 to the current segment.
+func (p *bigIntParser) push(value uint64) error {
+	if value >= i十 && p.segmentZero >= 0 {
+		// Example: 一千零五十 -> the zero skips 百, so the next unit must be <= 十
+		if decimalExponent(value) > p.segmentZero-2 {
 			return ErrInvalidSequence
 		}
-		p.minSegmentDigit = digit
+		p.segmentZero = -1
 	}
-	lastIndex := p.segmentIndex - 1
-	lastDigit := &p.segment[lastIndex]
-	if lastDigit.Cmp(digit) < 0 {
-		if digit.Cmp(&b十) < 0 {
-			return ErrInvalidSequence
-		} else if (digit.Cmp(&b百) == 0 || digit.Cmp(&b千) == 0) && lastDigit.Cmp(&b十) >= 0 {
-			return ErrInvalidSequence
+	if !p.seg.push(value) {
+		return ErrInvalidSequence
+	}
+	return nil
+}
+
+// zero adds a zero placeholder at the given offset, which must follow a unit and
+// skip at least one unit.
+func (p *bigIntParser) zero(offset int) error {
+	switch {
+	case !p.rules.ZeroPlaceholders || p.segmentZero >= 0:
+		return ErrInvalidSequence
+	case p.seg.unit > 0 && p.seg.digit == 0:
+		// Example: 一千零五
+		p.segmentZero, p.segmentZeroOffset = decimalExponent(p.seg.unit), offset
+	case p.seg.value() == 0 && p.lastUnit >= 0 && p.unitZero < 0:
+		// Example: 一万零五百, the zero follows the last digit of the multiplier
+		p.unitZero, p.unitZeroOffset = p.rules.System.exponent(p.lastUnit), offset
+		if p.count > 0 {
+			for m := p.units[p.count-1].multiplier; m%10 == 0; m /= 10 {
+				p.unitZero++
+			}
 		}
-		// Example: 二十 -> 2 * 10 -> 20
-		lastDigit.Mul(lastDigit, digit)
-		return nil
-	} else if lastDigit.Cmp(digit) > 0 && p.segmentIndex < len(p.segment) && (digit.Cmp(&b十) >= 0 || lastDigit.Cmp(&b十) >= 0) {
-		// Example: 十一 -> 20 + 1 -> 21
-		// We don't know if the next digit needs be multiplied with this digit or
-		// added to the last. Store for handling at the end of the segment.
-		p.append(digit)
-		return nil
+	default:
+		return ErrInvalidSequence
 	}
-	return ErrInvalidSequence
+	p.applied |= ZeroPlaceholder
+	return nil
 }
 
-// endSegmentWith is a combination of push() and endSegment() for digits >= 万 (10000).
-func (p *bigIntParser) endSegmentWith(digit *big.Int) error {
-	if p.segmentIndex == 0 || (p.minSegmentEndDigit != nil && p.minSegmentEndDigit.Cmp(digit) <= 0) {
+// endSegmentWith ends the current segment with the unit myriadUnits[k] >= 万.
+func (p *bigIntParser) endSegmentWith(k int) error {
+	if err := p.checkSegmentZero(); err != nil {
+		return err
+	}
+	biggestUnit := len(myriadUnits) - 1
+	if k == biggestUnit && p.rules.UnboundedBiggestUnit && (p.count > 0 || p.sum != nil && p.sum.Sign() != 0) {
+		return p.multiplyWithBiggestUnit()
+	}
+	// the units that are smaller than k belong to the multiplier of k
+	j := p.count
+	for j > 0 && p.units[j-1].unit < k {
+		j--
+	}
+	if j > 0 && p.units[j-1].unit == k {
+		if k == biggestUnit {
+			// the multiplier of the biggest unit would contain the biggest unit
+			return ErrOverflow
+		}
 		return ErrInvalidSequence
 	}
-	p.minSegmentEndDigit = digit
-	var multiplierSum big.Int
-	var lastDigit *big.Int
-	for i := 0; i < p.segmentIndex; i++ {
-		segmentDigit := &p.segment[i]
-		if lastDigit != nil && segmentDigit.Cmp(lastDigit) >= 0 {
+	shift := 0
+	if j == p.count {
+		shift = p.rules.System.exponent(k)
+	}
+	if err := p.checkUnitZero(shift); err != nil {
+		return err
+	}
+	limit := p.rules.multiplierLimit(k)
+	multiplier := p.seg.value()
+	for _, u := range p.units[j:p.count] {
+		exponent := p.rules.System.exponent(u.unit)
+		if exponent >= limit {
+			return p.limitError(k)
+		}
+		multiplier += u.multiplier * powersOfTen[exponent]
+	}
+	if multiplier == 0 {
+		if k >= p.rules.ImplicitOne {
 			return ErrInvalidSequence
 		}
-		multiplierSum.Add(&multiplierSum, segmentDigit)
-		lastDigit = segmentDigit
+		// Example: 만 -> 10^4 in Korean
+		multiplier = 1
+	}
+	if multiplier >= powersOfTen[limit] {
+		return p.limitError(k)
 	}
-	if multiplierSum.Cmp(&b万) >= 0 || multiplierSum.Cmp(digit) >= 0 {
+	if j > 0 && p.rules.System.exponent(k)+decimalExponent(multiplier) >= p.rules.System.exponent(p.units[j-1].unit) {
+		// Example: 一兆五十万亿 is invalid, because 五十万亿 >= 一兆
 		return ErrInvalidSequence
 	}
-	multiplierSum.Mul(&multiplierSum, digit)
-	p.sum.Add(p.sum, &multiplierSum)
-	p.clearSegment()
+	p.units[j] = unitMultiplier{k, multiplier}
+	p.count = j + 1
+	p.lastUnit = k
+	p.seg = segment{}
 	return nil
 }
 
-func (p *bigIntParser) endSegment() error {
-	if p.segmentIndex == 0 {
+// limitError returns the error for a multiplier of the k-th unit with too many
+// digits. Only the biggest unit can not be followed by a bigger one.
+func (p *bigIntParser) limitError(k int) error {
+	if k == len(myriadUnits)-1 {
+		return ErrOverflow
+	}
+	return ErrInvalidSequence
+}
+
+// multiplyWithBiggestUnit handles a repeated biggest unit, which multiplies
+// everything in front of it. Example: 一万無量大数 -> 10^4 * 10^68 -> 10^72
+func (p *bigIntParser) multiplyWithBiggestUnit() error {
+	if err := p.checkUnitZero(0); err != nil {
+		return err
+	}
+	if p.sum == nil {
+		return ErrOverflow
+	}
+	p.bigInt()
+	p.product.Mul(p.sum, &p.rules.System.values()[len(myriadUnits)-1])
+	p.sum.Set(&p.product)
+	p.count = 0
+	p.lastUnit = len(myriadUnits) - 1
+	return nil
+}
+
+// checkSegmentZero checks the zero placeholder in the current segment at its end.
+// The error refers to the zero.
+func (p *bigIntParser) checkSegmentZero() error {
+	if p.segmentZero < 0 {
 		return nil
 	}
-	var segmentSum big.Int
-	var lastDigit *big.Int
-	for i := 0; i < p.segmentIndex; i++ {
-		segmentDigit := &p.segment[i]
-		if lastDigit != nil && segmentDigit.Cmp(lastDigit) >= 0 {
-			return ErrInvalidSequence
-		}
-		segmentSum.Add(&segmentSum, segmentDigit)
-		lastDigit = segmentDigit
+	// Example: 一千零 and 一十零五 are invalid
+	if p.seg.digit == 0 || p.segmentZero < 2 {
+		return errorAt(p.segmentZeroOffset, ErrInvalidSequence)
 	}
-	if segmentSum.Cmp(&b万) >= 0 {
-		return ErrInvalidSequence
+	p.segmentZero = -1
+	return nil
+}
+
+// checkUnitZero checks the zero placeholder in front of the current segment at its
+// end. The exponent of the segment is shifted by the given power of ten if the
+// segment is not the multiplier of the unit in front of the zero. The error refers
+// to the zero.
+func (p *bigIntParser) checkUnitZero(shift int) error {
+	if p.unitZero < 0 {
+		return nil
+	}
+	value := p.seg.value()
+	// Example: 一万零五千 is invalid, because no unit is skipped
+	if value == 0 || decimalExponent(value)+shift > p.unitZero-2 {
+		return errorAt(p.unitZeroOffset, ErrInvalidSequence)
+	}
+	p.unitZero = -1
+	return nil
+}
+
+// end checks the zero placeholders of the last segment and applies the implicit
+// next unit.
+func (p *bigIntParser) end() error {
+	// a digit after a zero placeholder has no implicit unit
+	implicit := p.rules.ImplicitNextUnit && p.seg.digit > 0 && p.segmentZero < 0
+	afterUnit := p.unitZero < 0
+	if err := p.checkSegmentZero(); err != nil {
+		return err
+	}
+	if err := p.checkUnitZero(0); err != nil || !implicit {
+		return err
 	}
-	p.sum.Add(p.sum, &segmentSum)
+	switch {
+	case p.seg.unit >= i百:
+		// Example: 三千五 -> 3500
+		p.seg.sum += p.seg.digit * (p.seg.unit / 10)
+		p.seg.digit = 0
+	case !afterUnit || p.seg.unit > 0 || p.lastUnit < 0:
+		// Example: 十五 -> 15
+		return nil
+	case p.lastUnit == 0:
+		// Example: 三万五 -> 35000
+		p.seg.digit *= powersOfTen[p.rules.System.exponent(0)-1]
+	default:
+		// Example: 三億五 -> 350000000, which is 5000万 of the next lower unit
+		k := p.lastUnit - 1
+		exponent := p.rules.System.exponent(p.lastUnit) - 1 - p.rules.System.exponent(k)
+		p.units[p.count] = unitMultiplier{k, p.seg.digit * powersOfTen[exponent]}
+		p.count++
+		p.seg.digit = 0
+	}
+	p.applied |= ImplicitNextUnit
 	return nil
 }
 
+// bigInt sets sum to the parsed number and returns it.
+func (p *bigIntParser) bigInt() *big.Int {
+	values := p.rules.System.values()
+	words := p.sum.Bits()
+	for _, u := range p.units[:p.count] {
+		words = addMulWords(words, values[u.unit].Bits(), big.Word(u.multiplier))
+	}
+	one := [...]big.Word{1}
+	p.sum.SetBits(addMulWords(words, one[:], big.Word(p.seg.value())))
+	p.seg = segment{}
+	return p.sum
+}
+
+// uint64 returns the parsed number or ErrOverflow if it does not fit into uint64.
+func (p *bigIntParser) uint64() (uint64, error) {
+	if p.sum != nil && p.sum.Sign() != 0 {
+		return 0, ErrOverflow
+	}
+	sum := p.seg.value()
+	for _, u := range p.units[:p.count] {
+		exponent := p.rules.System.exponent(u.unit)
+		if exponent >= len(powersOfTen) {
+			return 0, ErrOverflow
+		}
+		hi, lo := bits.Mul64(u.multiplier, powersOfTen[exponent])
+		var carry uint64
+		sum, carry = bits.Add64(sum, lo, 0)
+		if hi > 0 || carry > 0 {
+			return 0, ErrOverflow
+		}
+	}
+	return sum, nil
+}
+
+// decimalExponent returns the power of ten of the most significant digit of u > 0.
+func decimalExponent(u uint64) int {
+	exponent := 0
+	for exponent+1 < len(powersOfTen) && u >= powersOfTen[exponent+1] {
+		exponent++
+	}
+	return exponent
+}
+
+// powersOfTen contains all powers of ten that fit into uint64.
+var powersOfTen = [...]uint64{
+	1, 10, 100, 1_000, 10_000, 100_000, 1_000_000, 10_000_000, 100_000_000, 1_000_000_000,
+	10_000_000_000, 100_000_000_000, 1_000_000_000_000, 10_000_000_000_000,
+	100_000_000_000_000, 1_000_000_000_000_000, 10_000_000_000_000_000,
+	100_000_000_000_000_000, 1_000_000_000_000_000_000, 10_000_000_000_000_000_000,
+}
+
+// addMulWords returns z + x*y for the little-endian words of natural numbers like
+// big.Int.Bits. The memory of z is reused if it has enough capacity, which avoids
+// the allocations of temporary big.Int values.
+func addMulWords(z, x []big.Word, y big.Word) []big.Word {
+	for len(z) <= len(x) {
+		z = append(z, 0)
+	}
+	var carry uint
+	for i, xi := range x {
+		hi, lo := bits.Mul(uint(xi), uint(y))
+		var c uint
+		lo, c = bits.Add(lo, carry, 0)
+		hi += c
+		lo, c = bits.Add(uint(z[i]), lo, 0)
+		z[i] = big.Word(lo)
+		carry = hi + c
+	}
+	for i := len(x); carry != 0; i++ {
+		if i == len(z) {
+			z = append(z, 0)
+		}
+		var sum uint
+		sum, carry = bits.Add(uint(z[i]), carry, 0)
+		z[i] = big.Word(sum)
+	}
+	for len(z) > 0 && z[len(z)-1] == 0 {
+		z = z[:len(z)-1]
+	}
+	return z
+}
+
+// parse parses all of s and adds the last segment to the sum.
 func (p *bigIntParser) parse(s string) error {
+	i, err := p.scan(s)
+	switch {
+	case err == ErrInvalidSequence:
+		// zero is only valid if it is the only rune
+		return errorAt(i, checkUnexpectedRune(s[i:]))
+	case err == ErrEOF:
+		return incompleteUnitError(s, i)
+	case err != nil:
+		return err
+	case i < len(s):
+		return errorAt(i, checkUnexpectedRune(s[i:]))
+	}
+	return p.end()
+}
+
+// scan parses the numerals at the start of s up to the first rune without a value
+// and returns their end. The last segment is not checked by end yet. Invalid
+// sequences are returned as ParseError at the offending rune, a leading zero that
+// is followed by more runes as ErrInvalidSequence at the end of the zero and an
+// incomplete multi kanji unit as ErrEOF at its start.
+func (p *bigIntParser) scan(s string) (int, error) {
 	n := len(s)
 	i := 0
-	var expectedRunes stack
-loop:
-	for ; i < n-2; i += utf8KanjiBytes {
+	size := utf8KanjiBytes
+	for ; i < n-2; i += size {
 		r := decodeUtf8Kanji(i, s)
-		if skip, err := expectedRunes.pop(r); err != nil {
-			return err
-		} else if skip {
-			continue
+		size = utf8KanjiBytes
+		if s[i] >= utf8FourBytesLead {
+			r, size = utf8.DecodeRuneInString(s[i:])
 		}
-		value := bigIntValueOf(r)
-		if value != nil && value.Sign() > 0 {
-			var err error
-			if value.Cmp(&b万) < 0 {
+		var err error
+		if value, ok := p.alphabet.ValueOf(r); ok && value > 0 {
+			if value < i万 {
 				err = p.push(value)
 			} else {
-				err = p.endSegmentWith(value)
+				err = p.endSegmentWith(smallUnitIndex(value))
 			}
-			if err != nil {
-				return err
+		} else if ok {
+			// zero is only valid if it is the only rune or a placeholder
+			if i == 0 && size == n {
+				return n, nil
+			} else if i == 0 && !p.rules.ZeroPlaceholders {
+				return size, ErrInvalidSequence
 			}
-		} else {
-			switch r {
-			case '零', '〇':
-				if i == 0 {
-					i += utf8KanjiBytes
-					break loop
+			err = p.zero(i)
+		} else if k := bigUnitIndex(r); k >= 0 {
+			err = p.endSegmentWith(k)
+			if kanji := myriadUnits[k].Kanji; err == nil && len(kanji) > size {
+				if !strings.HasPrefix(s[i:], kanji) {
+					return i, ErrEOF
 				}
-				return ErrInvalidSequence
-			default:
-				return checkUnexpectedRune(s[i:])
+				size = len(kanji)
 			}
+		} else {
+			return i, nil
 		}
-		switch r {
-		case '恒': // 恒河沙 10^52
-			expectedRunes.push('沙', '河')
-		case '阿': // 阿僧祇 10^56
-			expectedRunes.push('祇', '僧')
-		case '那': // 那由他 10^60
-			expectedRunes.push('他', '由')
-		case '不': // 不可思議 10^64
-			expectedRunes.push('議', '思', '可')
-		case '無': // 無量大数
-			expectedRunes.push('数', '大', '量')
+		if err != nil {
+			return i, errorAt(i, err)
 		}
 	}
-	if i < n {
-		return checkUnexpectedRune(s[i:])
-	}
-	if !expectedRunes.empty() {
-		return ErrEOF
-	}
-	return p.endSegment()
-}
-
-// stack stores the expected runes for multi kanji numerals.
-type stack struct {
-	runes []rune
+	return i, nil
 }
 
-// push adds the given runes to the stack.
-func (s *stack) push(runes ...rune) {
-	s.runes = append(s.runes, runes...)
+// incompleteUnitError returns the error for the multi kanji unit at s[i:] that
+// does not match all runes of the unit.
+func incompleteUnitError(s string, i int) error {
+	kanji := myriadUnits[bigUnitIndex(decodeUtf8Kanji(i, s))].Kanji
+	for j := utf8KanjiBytes; j < len(kanji); {
+		if i+j >= len(s) {
+			return ErrEOF
+		}
+		expected, expectedSize := utf8.DecodeRuneInString(kanji[j:])
+		actual, _ := utf8.DecodeRuneInString(s[i+j:])
+		if actual != expected {
+			return errorAt(i+j, &UnexpectedRuneError{actual, expected})
+		}
+		j += expectedSize
+	}
+	return ErrEOF
 }
 
-// pop removes a rune from the stack and returns an error if the stack is not empty and the given rune does not match
-// the top of the stack.
-func (s *stack) pop(r rune) (skip bool, err error) {
-	if len(s.runes) == 0 {
-		return
+// bigUnitSize returns the number of bytes of the complete unit >= 垓 at the start
+// of s or 0.
+func bigUnitSize(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	k := bigUnitIndex(r)
+	if k < 0 {
+		return 0
+	} else if r == '𥝱' {
+		// alternative form of 秭
+		return size
+	} else if kanji := myriadUnits[k].Kanji; strings.HasPrefix(s, kanji) {
+		return len(kanji)
 	}
-	last := len(s.runes) - 1
-	if expected := s.runes[last]; expected != r {
-		return false, &UnexpectedRuneError{r, expected}
-	}
-	s.runes = s.runes[:last]
-	return true, nil
+	return 0
 }
 
-func (s *stack) empty() bool {
-	return len(s.runes) == 0
+// smallUnitIndex returns the index in myriadUnits of a value of ValueOf >= 万.
+func smallUnitIndex(value uint64) int {
+	switch value {
+	case i万:
+		return 0
+	case i億:
+		return 1
+	case i兆:
+		return 2
+	default:
+		return 3
+	}
 }
 
-// bigIntValueOf returns the value of the given japanese numeral. Expects only the first
-// rune of multi kanji numerals. The result must treated as read-only.
-func bigIntValueOf(r rune) *big.Int {
+// bigUnitIndex returns the index in myriadUnits of the units >= 垓 or -1. Expects
+// only the first rune of multi kanji numerals.
+func bigUnitIndex(r rune) int {
 	switch r {
-	case '零', '〇':
-		return &b零
-	case '一', '壱', '壹':
-		return &b一
-	case '二', '弐', '貳':
-		return &b二
-	case '三', '参', '參':
-		return &b三
-	case '四', '肆':
-		return &b四
-	case '五', '伍':
-		return &b五
-	case '六', '陸':
-		return &b六
-	case '七', '柒', '漆':
-		return &b七
-	case '八', '捌':
-		return &b八
-	case '九', '玖':
-		return &b九
-	case '十', '拾':
-		return &b十
-	case '百', '佰':
-		return &b百
-	case '千', '阡', '仟':
-		return &b千
-	case '万', '萬':
-		return &b万
-	case '億':
-		return &b億
-	case '兆':
-		return &b兆
-	case '京':
-		return &b京
 	case '垓':
-		return &b垓
-	case '秭':
-		return &b秭
+		return 4
+	case '秭', '𥝱':
+		return 5
 	case '穣':
-		return &b穣
+		return 6
 	case '溝':
-		return &b溝
+		return 7
 	case '澗':
-		return &b澗
+		return 8
 	case '正':
-		return &b正
+		return 9
 	case '載':
-		return &b載
+		return 10
 	case '極':
-		return &b極
-	// first rune of multi kanji numerals
+		return 11
 	case '恒':
-		return &b恒河沙
+		return 12
 	case '阿':
-		return &b阿僧祇
+		return 13
 	case '那':
-		return &b那由他
+		return 14
 	case '不':
-		return &b不可思議
+		return 15
 	case '無':
-		return &b無量大数
+		return 16
 	default:
-		return nil
+		return -1
 	}
 }
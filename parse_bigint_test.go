@@ -21,6 +21,9 @@ var parseBigIntCases = []parseBigIntTestCase{
 	{"二秭", newTestBigInt(2, 24, 0)},
 	{"一秭一", newTestBigInt(1, 24, 1)},
 	{"二秭二", newTestBigInt(2, 24, 2)},
+	{"一𥝱", newTestBigInt(1, 24, 0)},
+	{"二𥝱二", newTestBigInt(2, 24, 2)},
+	{"九千𥝱九", newTestBigInt(9000, 24, 9)},
 
 	{"一穣", newTestBigInt(1, 28, 0)},
 	{"二穣", newTestBigInt(2, 28, 0)},
@@ -114,10 +117,40 @@ func TestParseBigInt(t *testing.T) {
 	}
 }
 
+func TestParseBigIntInto(t *testing.T) {
+	var dst big.Int
+	for _, tc := range parseBigIntCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := ParseBigIntInto(&dst, tc.Text)
+			expectErrNil(t, err)
+			expectEqual(t, &dst, actual)
+			expectEqual(t, tc.Expected.String(), actual.String())
+		})
+	}
+	_, err := ParseBigIntInto(&dst, "一恒河一")
+	expectErrIs(t, ErrUnexpectedRune, err)
+}
+
+func TestParseBigIntIntoAllocs(t *testing.T) {
+	var dst big.Int
+	for _, tc := range parseBigIntCases {
+		// grow dst to the size of the biggest result first
+		ParseBigIntInto(&dst, tc.Text)
+	}
+	for _, tc := range parseBigIntCases {
+		allocs := testing.AllocsPerRun(10, func() {
+			ParseBigIntInto(&dst, tc.Text)
+		})
+		expectEqual(t, 0.0, allocs)
+	}
+}
+
 func TestParseBigIntError(t *testing.T) {
 	for _, tc := range commonErrorCases {
 		testParseBigIntError(t, tc.Text, tc.Expected)
 	}
+	testParseBigIntError(t, "\xe0\x80\x80", ErrEncoding)
+	testParseBigIntError(t, "一垓\xe0\x80\x80", ErrEncoding)
 	// 恒河沙
 	testParseBigIntError(t, "一恒", ErrEOF)
 	testParseBigIntError(t, "一恒a", ErrUnexpectedRune)
@@ -158,3 +191,65 @@ func testParseBigIntError(t *testing.T, str string, expectedErr error) {
 		}
 	})
 }
+
+func TestParseBigIntUnbounded(t *testing.T) {
+	for _, tc := range formatBigIntUnboundedCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			actual, err := ParseBigInt(tc.Expected)
+			if err != nil {
+				t.Errorf("err: %v", err)
+			}
+			if actual == nil || tc.Number.Cmp(actual) != 0 {
+				t.Errorf("expected: %s, actual: %s", tc.Number, actual)
+			}
+		})
+	}
+	testParseBigIntError(t, "無量大数無量大数", ErrInvalidSequence)
+	testParseBigIntError(t, "一無量大数一無量大数十百", ErrInvalidSequence)
+	testParseBigIntError(t, "一無量大数一万一万無量大数", ErrInvalidSequence)
+}
+
+var serialBigIntCases = []parseBigIntTestCase{
+	{"一八四四六七四四〇七三七〇九五五一六一六", newTestBigIntString("18446744073709551616")},
+	{"一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇", newTestBigInt(1, 20, 0)},
+	{"一二三四五六七八九〇一二三四五六七八九〇一二三四五六七八九〇", newTestBigIntString("123456789012345678901234567890")},
+	{negativePrefix + "一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇", newTestBigInt(-1, 20, 0)},
+}
+
+func newTestBigIntString(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
+
+func TestParseSerialBigInt(t *testing.T) {
+	for _, tc := range serialTestCases {
+		actual, err := ParseSerialBigInt(tc.String)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Value, actual.Int64())
+	}
+	for _, tc := range serialBigIntCases {
+		actual, err := ParseSerialBigInt(tc.Text)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected.String(), actual.String())
+	}
+}
+
+func TestParseSerialBigIntError(t *testing.T) {
+	testParseError(t, parseSerialErrorCases, ParseSerialBigInt)
+	for _, tc := range []struct {
+		Text     string
+		Offset   int
+		Expected error
+	}{
+		{"一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇十", 63, ErrInvalidSequence},
+		{"一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇x", 63, ErrUnexpectedRune},
+		{negativePrefix + "一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇十", 75, ErrInvalidSequence},
+	} {
+		_, err := ParseSerialBigInt(tc.Text)
+		expectErrIs(t, tc.Expected, err)
+		var parseErr *ParseError
+		expectEqual(t, true, errors.As(err, &parseErr))
+		expectEqual(t, "ParseSerialBigInt", parseErr.Func)
+		expectEqual(t, tc.Offset, parseErr.Offset)
+	}
+}
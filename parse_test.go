@@ -25,6 +25,10 @@ func TestParseUint(t *testing.T) {
 
 func TestParseUintError(t *testing.T) {
 	testParseError(t, uintOverflowTestCases, ParseUint)
+	testParseError(t, []parseErrorTestCase{
+		{"\xe0\x80\x80", ErrEncoding},
+		{"一\xe0\x80\x80", ErrEncoding},
+	}, ParseUint)
 }
 
 func TestParseSerialInt(t *testing.T) {
@@ -58,8 +62,8 @@ func TestParseUintUnexpectedRune(t *testing.T) {
 	if !errors.Is(err, ErrUnexpectedRune) {
 		t.Errorf("expected: %v, actual: %v, result: %d", ErrUnexpectedRune, err, value)
 	}
-	result, ok := err.(*UnexpectedRuneError)
-	if ok && expectedRune != result.Actual {
+	var result *UnexpectedRuneError
+	if errors.As(err, &result) && expectedRune != result.Actual {
 		t.Errorf("expected: %s, actual: %s, result: %d", string(expectedRune), string(result.Actual), value)
 	}
 }
@@ -70,8 +74,8 @@ func TestParseUintUnexpectedRuneAfterValid(t *testing.T) {
 	if !errors.Is(err, ErrUnexpectedRune) {
 		t.Errorf("expected: %v, actual: %v, result: %d", ErrUnexpectedRune, err, value)
 	}
-	result, ok := err.(*UnexpectedRuneError)
-	if ok && expectedRune != result.Actual {
+	var result *UnexpectedRuneError
+	if errors.As(err, &result) && expectedRune != result.Actual {
 		t.Errorf("expected: %s, actual: %s, result: %d", string(expectedRune), string(result.Actual), value)
 	}
 }
@@ -120,3 +124,43 @@ func BenchmarkQuickParseUint(b *testing.B) {
 		})
 	}
 }
+
+func TestParseSerialWidth(t *testing.T) {
+	for _, tc := range []struct {
+		Text     string
+		Expected int64
+		Width    int
+	}{
+		{"〇〇四二", 42, 4},
+		{"二〇二三", 2023, 4},
+		{"〇", 0, 1},
+		{"〇〇〇", 0, 3},
+		{negativePrefix + "〇四二", -42, 3},
+	} {
+		actual, width, err := ParseSerialIntWidth(tc.Text)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		expectEqual(t, tc.Width, width)
+		expectEqual(t, tc.Text, FormatSerialIntWidth(actual, width))
+		if tc.Expected >= 0 {
+			actualUint, width, err := ParseSerialUintWidth(tc.Text)
+			expectErrNil(t, err)
+			expectEqual(t, uint64(tc.Expected), actualUint)
+			expectEqual(t, tc.Width, width)
+			expectEqual(t, tc.Text, FormatSerialUintWidth(actualUint, width))
+		}
+	}
+	for _, tc := range parseSerialErrorCases {
+		_, width, err := ParseSerialUintWidth(tc.Text)
+		expectErrIs(t, tc.Expected, err)
+		expectEqual(t, 0, width)
+		_, width, err = ParseSerialIntWidth(tc.Text)
+		expectErrIs(t, tc.Expected, err)
+		expectEqual(t, 0, width)
+	}
+	_, _, err := ParseSerialIntWidth(negativePrefix + "〇十")
+	var parseErr *ParseError
+	expectEqual(t, true, errors.As(err, &parseErr))
+	expectEqual(t, "ParseSerialIntWidth", parseErr.Func)
+	expectEqual(t, 15, parseErr.Offset)
+}
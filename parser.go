@@ -0,0 +1,385 @@
+package jnumber
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// ErrNotCanonical is returned by a strict Parser if a string is not the canonical
+// output of the formatter.
+var ErrNotCanonical = errors.New("not canonical")
+
+// FormatStyle defines the formatter that produces the canonical form of a number
+// for a strict Parser.
+type FormatStyle int
+
+const (
+	// StyleDefault is the style of FormatInt, FormatUint and FormatBigIntUnbounded: 一万二千
+	StyleDefault FormatStyle = iota
+	// StyleDaiji is the style of FormatUint combined with ToDaiji: 壱萬弐千
+	StyleDaiji
+	// StyleHistorical is the style of FormatHistoricalInt and FormatHistoricalUint: 廿五
+	StyleHistorical
+	// StyleSerial is the style of FormatSerialInt and FormatSerialUint: 二〇二三
+	StyleSerial
+)
+
+// CanonicalRule is the rule of the canonical form that a string violates.
+type CanonicalRule int
+
+const (
+	// RuleStyle is violated by all spellings that are not covered by another rule,
+	// e.g. 十 instead of 拾 in StyleDaiji or 二十 instead of 廿 in StyleHistorical.
+	RuleStyle CanonicalRule = iota
+	// RuleExplicitOne is violated by a 一 in front of 十, 百 or 千: 一百
+	RuleExplicitOne
+	// RuleZeroForm is violated by 〇 as a stand-alone zero or 零 in serial numbers.
+	RuleZeroForm
+	// RuleDaiji is violated by current daiji outside of StyleDaiji: 壱
+	RuleDaiji
+	// RuleObsoleteDaiji is violated by obsolete daiji: 壹
+	RuleObsoleteDaiji
+	// RuleVariant is violated by alternative forms of a numeral: 𥝱 instead of 秭
+	RuleVariant
+	// RuleNegativeZero is violated by a negative zero: マイナス零
+	RuleNegativeZero
+)
+
+func (r CanonicalRule) String() string {
+	switch r {
+	case RuleStyle:
+		return "style"
+	case RuleExplicitOne:
+		return "explicit one"
+	case RuleZeroForm:
+		return "zero form"
+	case RuleDaiji:
+		return "daiji"
+	case RuleObsoleteDaiji:
+		return "obsolete daiji"
+	case RuleVariant:
+		return "variant"
+	case RuleNegativeZero:
+		return "negative zero"
+	default:
+		return "CanonicalRule(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+// CanonicalError is returned by a strict Parser if a string is a valid numeral, but
+// not the canonical output of the formatter.
+type CanonicalError struct {
+	// Rule is the violated rule.
+	Rule CanonicalRule
+	// Canonical is the canonical form of the parsed number.
+	Canonical string
+}
+
+func (e *CanonicalError) Error() string {
+	return "not canonical (" + e.Rule.String() + "), expected " + e.Canonical
+}
+
+func (e *CanonicalError) Is(err error) bool {
+	return err == ErrNotCanonical
+}
+
+// LenientRule is a set of colloquial rules that a lenient Parser applies.
+type LenientRule uint8
+
+const (
+	// ImplicitNextUnit multiplies a single digit at the end of the number with the
+	// next lower unit of the unit in front of it: 三百五 (350), 二万五 (25,000)
+	ImplicitNextUnit LenientRule = 1 << iota
+	// ZeroPlaceholder allows 〇 or 零 in place of skipped units: 千〇五 (1005)
+	ZeroPlaceholder
+)
+
+func (r LenientRule) String() string {
+	var names []string
+	if r&ImplicitNextUnit != 0 {
+		names = append(names, "implicit next unit")
+	}
+	if r&ZeroPlaceholder != 0 {
+		names = append(names, "zero placeholder")
+	}
+	if rest := r &^ (ImplicitNextUnit | ZeroPlaceholder); rest != 0 {
+		names = append(names, "LenientRule("+strconv.Itoa(int(rest))+")")
+	}
+	return strings.Join(names, "|")
+}
+
+// lenientRules contains the rules of a lenient Parser.
+var lenientRules = numeralRules{
+	System:               Myriad,
+	ZeroPlaceholders:     true,
+	ImplicitNextUnit:     true,
+	UnboundedBiggestUnit: true,
+}
+
+// Parser parses japanese numerals with a configurable style. The zero value
+// behaves like ParseInt, ParseUint and ParseBigInt.
+type Parser struct {
+	// Strict rejects all strings that are not the canonical output of the formatter
+	// of Style with a CanonicalError, e.g. 一百, 〇, 壱 or 𥝱 in StyleDefault.
+	// Strict also rejects all numerals that need a lenient rule.
+	Strict bool
+	// Lenient accepts colloquial numerals with the rules ImplicitNextUnit and
+	// ZeroPlaceholder. A zero placeholder takes precedence over the implicit next
+	// unit, so 千〇五 is 1005, but 千五 is 1500. A digit after 十 is never
+	// multiplied, so 十五 is still 15. The implicit next unit takes precedence
+	// over the canonical form, so 百七 (FormatUint(107)) is 170 and 三百三万六百七
+	// is 3030670. Use 百〇七 for 107. Lenient has no effect on StyleSerial.
+	Lenient bool
+	// Style defines the accepted numerals and the canonical form. StyleHistorical
+	// additionally accepts the historical forms of ParseHistoricalInt and
+	// StyleSerial accepts only serial numbers.
+	Style FormatStyle
+	// Signs defines the accepted signs of ParseInt and ParseBigInt and the sign of
+	// the canonical form. Nil accepts only マイナス.
+	Signs *Signs
+}
+
+// ParseInt returns the integer represented by the given japanese numerals.
+func (p Parser) ParseInt(s string) (int64, error) {
+	i, _, err := p.parseInt(s)
+	if err != nil {
+		return 0, newParseError("Parser.ParseInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseUint returns the unsigned integer represented by the given japanese numerals.
+func (p Parser) ParseUint(s string) (uint64, error) {
+	u, _, err := p.parseUint(s)
+	if err != nil {
+		return 0, newParseError("Parser.ParseUint", s, err)
+	}
+	return u, nil
+}
+
+// ParseBigInt returns the integer represented by the given japanese numerals.
+func (p Parser) ParseBigInt(s string) (*big.Int, error) {
+	i, _, err := p.parseBigInt(s)
+	if err != nil {
+		return nil, newParseError("Parser.ParseBigInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseIntRules is like ParseInt, but also returns the lenient rules that were
+// applied to parse the numerals.
+func (p Parser) ParseIntRules(s string) (int64, LenientRule, error) {
+	i, rules, err := p.parseInt(s)
+	if err != nil {
+		return 0, 0, newParseError("Parser.ParseIntRules", s, err)
+	}
+	return i, rules, nil
+}
+
+// ParseUintRules is like ParseUint, but also returns the lenient rules that were
+// applied to parse the numerals.
+func (p Parser) ParseUintRules(s string) (uint64, LenientRule, error) {
+	u, rules, err := p.parseUint(s)
+	if err != nil {
+		return 0, 0, newParseError("Parser.ParseUintRules", s, err)
+	}
+	return u, rules, nil
+}
+
+// ParseBigIntRules is like ParseBigInt, but also returns the lenient rules that were
+// applied to parse the numerals.
+func (p Parser) ParseBigIntRules(s string) (*big.Int, LenientRule, error) {
+	i, rules, err := p.parseBigInt(s)
+	if err != nil {
+		return nil, 0, newParseError("Parser.ParseBigIntRules", s, err)
+	}
+	return i, rules, nil
+}
+
+func (p Parser) parseInt(s string) (int64, LenientRule, error) {
+	abs, isNegative := p.Signs.cut(s)
+	u, rules, err := p.parseAbs(abs)
+	if err != nil {
+		return 0, 0, shiftError(err, len(s)-len(abs))
+	}
+	i, err := toInt64(u, isNegative)
+	if err == nil && p.Strict {
+		err = p.checkCanonical(s, p.formatInt(i))
+	}
+	return i, rules, err
+}
+
+func (p Parser) parseUint(s string) (uint64, LenientRule, error) {
+	u, rules, err := p.parseAbs(s)
+	if err == nil && p.Strict {
+		err = p.checkCanonical(s, p.formatUint(u))
+	}
+	return u, rules, err
+}
+
+func (p Parser) parseBigInt(s string) (*big.Int, LenientRule, error) {
+	abs, isNegative := p.Signs.cut(s)
+	shift := len(s) - len(abs)
+	if strings.HasPrefix(abs, negativePrefix) {
+		return nil, 0, errorAt(shift, checkUnexpectedRune(abs))
+	}
+	i, rules, err := p.parseAbsBigInt(abs)
+	if err != nil {
+		return nil, 0, shiftError(err, shift)
+	}
+	if isNegative {
+		i.Neg(i)
+	}
+	if p.Strict {
+		err = p.checkCanonical(s, p.formatBigInt(i))
+	}
+	return i, rules, err
+}
+
+// parseAbs parses numerals without a sign.
+func (p Parser) parseAbs(s string) (u uint64, rules LenientRule, err error) {
+	switch {
+	case p.Style == StyleSerial:
+		u, err = parseSerialUint(s)
+	case p.Lenient:
+		parser := newBigIntParser(nil, &lenientRules, nil)
+		if err = p.parseLenient(&parser, s); err == nil {
+			u, err = parser.uint64()
+			rules = parser.applied
+		}
+	case p.Style == StyleHistorical:
+		u, err = parseHistoricalUint(s)
+	default:
+		u, err = parseUint(s, nil)
+	}
+	return u, rules, err
+}
+
+// parseAbsBigInt parses numerals without a sign.
+func (p Parser) parseAbsBigInt(s string) (i *big.Int, rules LenientRule, err error) {
+	switch {
+	case p.Style == StyleSerial:
+		i, err = parseSerialBigUint(s)
+	case p.Lenient:
+		parser := newBigIntParser(new(big.Int), &lenientRules, nil)
+		if err = p.parseLenient(&parser, s); err == nil {
+			i, rules = parser.bigInt(), parser.applied
+		}
+	case p.Style == StyleHistorical:
+		i, err = parseHistoricalBigInt(s)
+	default:
+		i, err = parseBigInt(s, nil)
+	}
+	return i, rules, err
+}
+
+// parseLenient parses the numerals with lenientRules. The historical forms are
+// replaced first in StyleHistorical.
+func (p Parser) parseLenient(parser *bigIntParser, s string) error {
+	if s == "" {
+		return ErrEmpty
+	}
+	if p.Style != StyleHistorical {
+		return parser.parse(s)
+	}
+	normalized, err := fromHistorical(s)
+	if err == nil {
+		err = parser.parse(normalized)
+	}
+	if err != nil {
+		return historicalError(s, err)
+	}
+	return nil
+}
+
+func (p Parser) formatInt(i int64) string {
+	switch p.Style {
+	case StyleDaiji:
+		return toDaijiReplacer.Replace(p.Signs.FormatInt(i))
+	case StyleHistorical:
+		return p.Signs.FormatHistoricalInt(i)
+	case StyleSerial:
+		return p.Signs.FormatSerialInt(i)
+	default:
+		return p.Signs.FormatInt(i)
+	}
+}
+
+func (p Parser) formatUint(u uint64) string {
+	switch p.Style {
+	case StyleDaiji:
+		return toDaijiReplacer.Replace(FormatUint(u))
+	case StyleHistorical:
+		return FormatHistoricalUint(u)
+	case StyleSerial:
+		return FormatSerialUint(u)
+	default:
+		return FormatUint(u)
+	}
+}
+
+func (p Parser) formatBigInt(i *big.Int) string {
+	var abs big.Int
+	abs.Abs(i)
+	dst := p.Signs.appendSign(make([]byte, 0, initialFormatBufferSize), i.Sign())
+	switch p.Style {
+	case StyleDaiji:
+		dst = append(dst, toDaijiReplacer.Replace(FormatBigIntUnbounded(&abs))...)
+	case StyleHistorical:
+		var buffer [128]byte
+		dst = appendHistorical(dst, AppendBigIntUnbounded(buffer[:0], &abs))
+	case StyleSerial:
+		dst = AppendSerialBigInt(dst, &abs)
+	default:
+		dst = AppendBigIntUnbounded(dst, &abs)
+	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// checkCanonical returns a CanonicalError at the first rune of s that differs from
+// the canonical form or nil if s is canonical.
+func (p Parser) checkCanonical(s, canonical string) error {
+	if s == canonical {
+		return nil
+	}
+	offset := 0
+	for offset < len(s) && offset < len(canonical) && s[offset] == canonical[offset] {
+		offset++
+	}
+	for offset > 0 && offset < len(s) && !utf8.RuneStart(s[offset]) {
+		offset--
+	}
+	return errorAt(offset, &CanonicalError{p.canonicalRule(s, offset, canonical), canonical})
+}
+
+// canonicalRule returns the rule violated by the rune of s at the given offset.
+func (p Parser) canonicalRule(s string, offset int, canonical string) CanonicalRule {
+	if offset == len(s) {
+		return RuleStyle
+	}
+	if _, isNegative := p.Signs.cut(s); isNegative && !strings.HasPrefix(canonical, p.Signs.negative()) {
+		return RuleNegativeZero
+	}
+	r, size := utf8.DecodeRuneInString(s[offset:])
+	switch {
+	case r == '〇' || r == '零':
+		return RuleZeroForm
+	case r == '𥝱':
+		return RuleVariant
+	case strings.ContainsRune(obsoletDajiRunes, r):
+		return RuleObsoleteDaiji
+	case p.Style != StyleDaiji && strings.ContainsRune(daijiRunes, r):
+		return RuleDaiji
+	}
+	if value, ok := ValueOf(r); ok && value == i一 && p.Style != StyleSerial {
+		next, _ := utf8.DecodeRuneInString(s[offset+size:])
+		if unit, ok := ValueOf(next); ok && (unit == i十 || unit == i百 || unit == i千) {
+			return RuleExplicitOne
+		}
+	}
+	return RuleStyle
+}
@@ -0,0 +1,228 @@
+package jnumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParserNotStrict(t *testing.T) {
+	for _, tc := range []struct {
+		Style    FormatStyle
+		Input    string
+		Expected int64
+	}{
+		{StyleDefault, "一百", 100},
+		{StyleDefault, "〇", 0},
+		{StyleDefault, "壱萬", 10_000},
+		{StyleDaiji, "十", 10},
+		{StyleHistorical, "廿五", 25},
+		{StyleHistorical, "十有五", 15},
+		{StyleSerial, "二〇二三", 2023},
+		{StyleSerial, "マイナス一〇", -10},
+	} {
+		actual, err := Parser{Style: tc.Style}.ParseInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+	}
+	_, err := Parser{Style: StyleSerial}.ParseInt("十")
+	expectErrIs(t, ErrInvalidSequence, err)
+}
+
+func TestParserStrict(t *testing.T) {
+	for _, tc := range []struct {
+		Style    FormatStyle
+		Input    string
+		Expected uint64
+	}{
+		{StyleDefault, "零", 0},
+		{StyleDefault, "十", 10},
+		{StyleDefault, "一万", 10_000},
+		{StyleDefault, "千百十一", 1111},
+		{StyleDaiji, "壱萬弐千参百四拾五", 12345},
+		{StyleDaiji, "零", 0},
+		{StyleHistorical, "廿五", 25},
+		{StyleHistorical, "百卌", 140},
+		{StyleSerial, "〇", 0},
+		{StyleSerial, "二〇二三", 2023},
+	} {
+		p := Parser{Strict: true, Style: tc.Style}
+		actual, err := p.ParseUint(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		actualInt, err := p.ParseInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, int64(tc.Expected), actualInt)
+		actualBigInt, err := p.ParseBigInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actualBigInt.Uint64())
+	}
+}
+
+func TestParserStrictBigInt(t *testing.T) {
+	serial, err := Parser{Strict: true, Style: StyleSerial}.ParseBigInt("マイナス一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(-1, 20, 0).String(), serial.String())
+	p := Parser{Strict: true}
+	for _, s := range []string{"一垓", "マイナス一無量大数", "一万無量大数"} {
+		_, err := p.ParseBigInt(s)
+		expectErrNil(t, err)
+	}
+	_, err = p.ParseBigInt("一𥝱")
+	expectErrIs(t, ErrNotCanonical, err)
+}
+
+func TestParserStrictError(t *testing.T) {
+	for _, tc := range []struct {
+		Style     FormatStyle
+		Input     string
+		Offset    int
+		Rule      CanonicalRule
+		Canonical string
+	}{
+		{StyleDefault, "一十", 0, RuleExplicitOne, "十"},
+		{StyleDefault, "二千一百", 6, RuleExplicitOne, "二千百"},
+		{StyleDefault, "一千万", 0, RuleExplicitOne, "千万"},
+		{StyleDefault, "〇", 0, RuleZeroForm, "零"},
+		{StyleDefault, "壱", 0, RuleDaiji, "一"},
+		{StyleDefault, "二拾", 3, RuleDaiji, "二十"},
+		{StyleDefault, "壹", 0, RuleObsoleteDaiji, "一"},
+		{StyleDefault, "マイナス零", 0, RuleNegativeZero, "零"},
+		{StyleDaiji, "壱拾", 0, RuleExplicitOne, "拾"},
+		{StyleDaiji, "十", 0, RuleStyle, "拾"},
+		{StyleDaiji, "佰", 0, RuleObsoleteDaiji, "百"},
+		{StyleHistorical, "二十五", 0, RuleStyle, "廿五"},
+		{StyleHistorical, "十有五", 3, RuleStyle, "十五"},
+		{StyleSerial, "零", 0, RuleZeroForm, "〇"},
+		{StyleSerial, "一零", 3, RuleZeroForm, "一〇"},
+		{StyleSerial, "〇一", 0, RuleZeroForm, "一"},
+	} {
+		_, err := Parser{Strict: true, Style: tc.Style}.ParseInt(tc.Input)
+		expectErrIs(t, ErrNotCanonical, err)
+		var parseErr *ParseError
+		expectEqual(t, true, errors.As(err, &parseErr))
+		expectEqual(t, "Parser.ParseInt", parseErr.Func)
+		expectEqual(t, tc.Offset, parseErr.Offset)
+		var canonicalErr *CanonicalError
+		expectEqual(t, true, errors.As(err, &canonicalErr))
+		expectEqual(t, tc.Rule, canonicalErr.Rule)
+		expectEqual(t, tc.Canonical, canonicalErr.Canonical)
+	}
+}
+
+func TestParserStrictErrorMessage(t *testing.T) {
+	_, err := Parser{Strict: true}.ParseUint("一百")
+	expectEqual(t, `jnumber.Parser.ParseUint: parsing "一百": not canonical (explicit one), expected 百 at offset 0`, err.Error())
+}
+
+func TestParserStrictRoundTrip(t *testing.T) {
+	for _, style := range []FormatStyle{StyleDefault, StyleDaiji, StyleHistorical, StyleSerial} {
+		p := Parser{Strict: true, Style: style}
+		for _, u := range []uint64{0, 1, 10, 11, 20, 101, 1000, 10_000, 12_345_678, 1<<64 - 1} {
+			actual, err := p.ParseUint(p.formatUint(u))
+			expectErrNil(t, err)
+			expectEqual(t, u, actual)
+		}
+	}
+}
+
+func TestCanonicalRuleString(t *testing.T) {
+	expectEqual(t, "explicit one", RuleExplicitOne.String())
+	expectEqual(t, "negative zero", RuleNegativeZero.String())
+	expectEqual(t, "CanonicalRule(99)", CanonicalRule(99).String())
+}
+
+func TestParserLenient(t *testing.T) {
+	for _, tc := range []struct {
+		Style    FormatStyle
+		Input    string
+		Expected int64
+		Rules    LenientRule
+	}{
+		{StyleDefault, "二万五", 25_000, ImplicitNextUnit},
+		{StyleDefault, "三百五", 350, ImplicitNextUnit},
+		{StyleDefault, "千五", 1500, ImplicitNextUnit},
+		{StyleDefault, "二万五千五", 25_500, ImplicitNextUnit},
+		{StyleDefault, "二億五", 250_000_000, ImplicitNextUnit},
+		{StyleDefault, "千〇五", 1005, ZeroPlaceholder},
+		{StyleDefault, "千零五", 1005, ZeroPlaceholder},
+		{StyleDefault, "二万〇五", 20_005, ZeroPlaceholder},
+		{StyleDefault, "マイナス三百五", -350, ImplicitNextUnit},
+		{StyleDefault, "十五", 15, 0},
+		{StyleDefault, "三百五十", 350, 0},
+		{StyleDefault, "〇", 0, 0},
+		{StyleDaiji, "弐萬五", 25_000, ImplicitNextUnit},
+		{StyleHistorical, "百廿", 120, 0},
+		{StyleHistorical, "千〇廿", 1020, ZeroPlaceholder},
+		{StyleSerial, "三〇五", 305, 0},
+	} {
+		p := Parser{Lenient: true, Style: tc.Style}
+		actual, rules, err := p.ParseIntRules(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		expectEqual(t, tc.Rules, rules)
+		actualBigInt, rules, err := p.ParseBigIntRules(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actualBigInt.Int64())
+		expectEqual(t, tc.Rules, rules)
+	}
+}
+
+func TestParserLenientCanonical(t *testing.T) {
+	// the implicit next unit changes the value of some canonical numerals
+	p := Parser{Lenient: true}
+	for _, tc := range []struct {
+		Input     string
+		Expected  uint64
+		Canonical uint64
+	}{
+		{"百七", 170, 107},
+		{"三百三万六百七", 3_030_670, 3_030_607},
+		{"二万五", 25_000, 20_005},
+	} {
+		expectEqual(t, tc.Input, FormatUint(tc.Canonical))
+		actual, rules, err := p.ParseUintRules(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		expectEqual(t, ImplicitNextUnit, rules)
+	}
+}
+
+func TestParserLenientError(t *testing.T) {
+	for _, tc := range []struct {
+		Style    FormatStyle
+		Input    string
+		Offset   int
+		Expected error
+	}{
+		{StyleDefault, "", 0, ErrEmpty},
+		{StyleDefault, "〇五", 0, ErrInvalidSequence},
+		{StyleDefault, "十〇五", 3, ErrInvalidSequence},
+		{StyleDefault, "千〇", 3, ErrInvalidSequence},
+		{StyleDefault, "一一", 3, ErrInvalidSequence},
+		{StyleDefault, "マイナス一一", 15, ErrInvalidSequence},
+		{StyleHistorical, "廿廿", 3, ErrInvalidSequence},
+	} {
+		_, _, err := Parser{Lenient: true, Style: tc.Style}.ParseIntRules(tc.Input)
+		expectErrIs(t, tc.Expected, err)
+		var parseErr *ParseError
+		expectEqual(t, true, errors.As(err, &parseErr))
+		expectEqual(t, "Parser.ParseIntRules", parseErr.Func)
+		expectEqual(t, tc.Offset, parseErr.Offset)
+	}
+}
+
+func TestParserLenientStrict(t *testing.T) {
+	p := Parser{Strict: true, Lenient: true}
+	_, err := p.ParseUint("三百五")
+	expectErrIs(t, ErrNotCanonical, err)
+	actual, err := p.ParseUint("三百五十")
+	expectErrNil(t, err)
+	expectEqual(t, uint64(350), actual)
+}
+
+func TestLenientRuleString(t *testing.T) {
+	expectEqual(t, "", LenientRule(0).String())
+	expectEqual(t, "implicit next unit", ImplicitNextUnit.String())
+	expectEqual(t, "implicit next unit|zero placeholder", (ImplicitNextUnit | ZeroPlaceholder).String())
+	expectEqual(t, "LenientRule(4)", LenientRule(4).String())
+}
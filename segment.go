@@ -0,0 +1,43 @@
+package jnumber
+
+// segment validates and sums up the numerals < 万 in front of a unit >= 万 or at the
+// end of a number, e.g. 三千二百五 of 三千二百五万. It is the common step of all
+// parsers of positional numerals, which only differ in the handling of the units
+// >= 万 and in the type of the sum.
+type segment struct {
+	// sum of the digits that have been multiplied with a unit
+	sum uint64
+	// digit 1 to 9 that has not been multiplied with a unit yet or 0
+	digit uint64
+	// smallest unit 十, 百 or 千 of the segment or 0
+	unit uint64
+}
+
+// push adds a digit 1 to 9 or one of the units 十, 百 and 千 to the segment and
+// reports whether the value may follow the previous ones: a digit must not follow
+// another digit and the units must be strictly decreasing.
+func (seg *segment) push(value uint64) bool {
+	if value < i十 {
+		if seg.digit > 0 {
+			return false
+		}
+		seg.digit = value
+		return true
+	}
+	if seg.unit > 0 && value >= seg.unit {
+		return false
+	}
+	seg.unit = value
+	if seg.digit > 0 {
+		// Example: 二十 -> 2 * 10 -> 20
+		value *= seg.digit
+		seg.digit = 0
+	}
+	seg.sum += value
+	return true
+}
+
+// value returns the sum of the segment, which is 0 for an empty segment.
+func (seg *segment) value() uint64 {
+	return seg.sum + seg.digit
+}
@@ -0,0 +1,365 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Signs defines the recognized signs of numbers and the signs of formatted numbers.
+// A nil *Signs behaves like DefaultSigns.
+type Signs struct {
+	// Negative contains the accepted prefixes of negative numbers. The first one is
+	// used for formatting, マイナス if Negative is empty.
+	Negative []string
+	// Positive contains the accepted prefixes of positive numbers. The first one is
+	// used for formatting if ShowPositive is true.
+	Positive []string
+	// ShowPositive prefixes formatted numbers > 0 with the first positive sign.
+	ShowPositive bool
+	// Spaces allows spaces (U+0020 and U+3000) between the sign and the number,
+	// e.g. マイナス 三.
+	Spaces bool
+}
+
+var (
+	// DefaultSigns accepts only マイナス like ParseInt and FormatInt.
+	DefaultSigns = &Signs{Negative: []string{negativePrefix}}
+	// AccountingSigns uses ▲ and accepts ▲ and △ for negative numbers like in
+	// japanese accounting documents.
+	AccountingSigns = &Signs{Negative: []string{"▲", "△"}}
+	// ExtendedSigns accepts マイナス, ﾏｲﾅｽ, 負, −, －, -, ▲ and △ for negative
+	// numbers, プラス, ﾌﾟﾗｽ, ＋ and + for positive numbers and spaces after the sign.
+	ExtendedSigns = &Signs{
+		Negative: []string{negativePrefix, "ﾏｲﾅｽ", "負", "−", "－", "-", "▲", "△"},
+		Positive: []string{"プラス", "ﾌﾟﾗｽ", "＋", "+"},
+		Spaces:   true,
+	}
+)
+
+// negative returns the prefix of formatted negative numbers.
+func (sg *Signs) negative() string {
+	if sg == nil || len(sg.Negative) == 0 {
+		return negativePrefix
+	}
+	return sg.Negative[0]
+}
+
+// appendSign appends the sign of a number with the given sign (-1, 0 or 1) to dst.
+func (sg *Signs) appendSign(dst []byte, sign int) []byte {
+	if sign < 0 {
+		return append(dst, sg.negative()...)
+	}
+	if sign > 0 && sg != nil && sg.ShowPositive && len(sg.Positive) > 0 {
+		return append(dst, sg.Positive[0]...)
+	}
+	return dst
+}
+
+// cut removes the longest matching sign and the following spaces from s and
+// returns the remaining numerals.
+func (sg *Signs) cut(s string) (abs string, isNegative bool) {
+	if sg == nil {
+		sg = DefaultSigns
+	}
+	size := 0
+	for _, prefix := range sg.Negative {
+		if len(prefix) > size && strings.HasPrefix(s, prefix) {
+			size, isNegative = len(prefix), true
+		}
+	}
+	for _, prefix := range sg.Positive {
+		if len(prefix) > size && strings.HasPrefix(s, prefix) {
+			size, isNegative = len(prefix), false
+		}
+	}
+	abs = s[size:]
+	if size > 0 && sg.Spaces {
+		abs = strings.TrimLeft(abs, " 　")
+	}
+	return abs, isNegative
+}
+
+// cutBefore returns the number of bytes of the sign and its spaces at the end of s,
+// where s[:previous] contains the previous numeral. A sign directly after the
+// previous numeral separates two numerals like in 一-二, and a sign that is a word
+// like 負 or マイナス must not continue a word of the same script like in 勝負三.
+func (sg *Signs) cutBefore(s string, previous int) (size int, isNegative bool) {
+	if sg == nil {
+		sg = DefaultSigns
+	}
+	trimmed := s
+	if sg.Spaces {
+		trimmed = strings.TrimRight(s, " 　")
+	}
+	for _, prefix := range sg.Negative {
+		if len(prefix) > size && strings.HasSuffix(trimmed, prefix) {
+			size, isNegative = len(prefix), true
+		}
+	}
+	for _, prefix := range sg.Positive {
+		if len(prefix) > size && strings.HasSuffix(trimmed, prefix) {
+			size, isNegative = len(prefix), false
+		}
+	}
+	if size == 0 {
+		return 0, false
+	}
+	if start := len(trimmed) - size; start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(trimmed[:start])
+		first, _ := utf8.DecodeRuneInString(trimmed[start:])
+		if start == previous || unicode.IsLetter(first) && sameScript(before, first) {
+			return 0, false
+		}
+	}
+	return size + len(s) - len(trimmed), isNegative
+}
+
+// sameScript reports whether both runes are kanji, hiragana, katakana or latin
+// letters.
+func sameScript(a, b rune) bool {
+	for _, script := range []*unicode.RangeTable{unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Latin} {
+		if unicode.Is(script, a) {
+			return unicode.Is(script, b)
+		}
+	}
+	return false
+}
+
+// ParseInt returns the integer represented by the given japanese numerals with one
+// of the signs.
+func (sg *Signs) ParseInt(s string) (int64, error) {
+	i, err := sg.parseInt(s, parseUintDefault)
+	if err != nil {
+		return 0, newParseError("Signs.ParseInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseBigInt returns the integer represented by the given japanese numerals with
+// one of the signs.
+func (sg *Signs) ParseBigInt(s string) (*big.Int, error) {
+	i, err := sg.parseBigInt(s, parseBigIntDefault)
+	if err != nil {
+		return nil, newParseError("Signs.ParseBigInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseSerialInt returns the integer represented by the given serial japanese
+// numerals with one of the signs.
+func (sg *Signs) ParseSerialInt(s string) (int64, error) {
+	i, err := sg.parseInt(s, parseSerialUint)
+	if err != nil {
+		return 0, newParseError("Signs.ParseSerialInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseSerialBigInt returns the integer represented by the given serial japanese
+// numerals with one of the signs.
+func (sg *Signs) ParseSerialBigInt(s string) (*big.Int, error) {
+	i, err := sg.parseBigInt(s, parseSerialBigUint)
+	if err != nil {
+		return nil, newParseError("Signs.ParseSerialBigInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseHistoricalInt returns the integer represented by the given japanese numerals
+// with one of the signs. See ParseHistoricalInt for the supported historical forms.
+func (sg *Signs) ParseHistoricalInt(s string) (int64, error) {
+	i, err := sg.parseInt(s, parseHistoricalUint)
+	if err != nil {
+		return 0, newParseError("Signs.ParseHistoricalInt", s, err)
+	}
+	return i, nil
+}
+
+// ParseHistoricalBigInt returns the integer represented by the given japanese
+// numerals with one of the signs. See ParseHistoricalInt for the supported
+// historical forms.
+func (sg *Signs) ParseHistoricalBigInt(s string) (*big.Int, error) {
+	i, err := sg.parseBigInt(s, parseHistoricalBigInt)
+	if err != nil {
+		return nil, newParseError("Signs.ParseHistoricalBigInt", s, err)
+	}
+	return i, nil
+}
+
+func parseUintDefault(s string) (uint64, error) {
+	return parseUint(s, nil)
+}
+
+func parseBigIntDefault(s string) (*big.Int, error) {
+	return parseBigInt(s, nil)
+}
+
+// parseInt removes the sign and parses the remaining numerals with parse.
+func (sg *Signs) parseInt(s string, parse func(string) (uint64, error)) (int64, error) {
+	abs, isNegative := sg.cut(s)
+	u, err := parse(abs)
+	if err != nil {
+		return 0, shiftError(err, len(s)-len(abs))
+	}
+	return toInt64(u, isNegative)
+}
+
+// parseBigInt removes the sign and parses the remaining numerals with parse, which
+// must not accept another sign.
+func (sg *Signs) parseBigInt(s string, parse func(string) (*big.Int, error)) (*big.Int, error) {
+	abs, isNegative := sg.cut(s)
+	if strings.HasPrefix(abs, negativePrefix) {
+		return nil, errorAt(len(s)-len(abs), checkUnexpectedRune(abs))
+	}
+	i, err := parse(abs)
+	if err != nil {
+		return nil, shiftError(err, len(s)-len(abs))
+	}
+	if isNegative {
+		i.Neg(i)
+	}
+	return i, nil
+}
+
+// AppendInt appends the given integer as japanese numerals with the sign to dst.
+func (sg *Signs) AppendInt(dst []byte, i int64) []byte {
+	dst = sg.appendSign(dst, sign(i))
+	return AppendUint(dst, absInt64(i))
+}
+
+// FormatInt returns the given integer as a string of japanese numerals with the sign.
+func (sg *Signs) FormatInt(i int64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = sg.AppendInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// AppendBigInt appends the given big integer as japanese numerals with the sign to
+// dst. Returns ErrOverflow if |i| >= 10^72.
+func (sg *Signs) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	if i.IsInt64() {
+		return sg.AppendInt(dst, i.Int64()), nil
+	}
+	initBigIntsOnce.Do(initBigInts)
+	if i.CmpAbs(&maxBigIntLimit) >= 0 {
+		return dst, ErrOverflow
+	}
+	return formatBigInt(sg.appendSign(dst, i.Sign()), i.Bits()), nil
+}
+
+// FormatBigInt returns the given big integer as a string of japanese numerals with
+// the sign. Returns ErrOverflow if |i| >= 10^72.
+func (sg *Signs) FormatBigInt(i *big.Int) (string, error) {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst, err := sg.AppendBigInt(dst, i)
+	if err != nil {
+		return "", err
+	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst)), nil
+}
+
+// AppendSerialInt appends the given integer as serial japanese numerals with the
+// sign to dst.
+func (sg *Signs) AppendSerialInt(dst []byte, i int64) []byte {
+	dst = sg.appendSign(dst, sign(i))
+	return AppendSerialUint(dst, absInt64(i))
+}
+
+// FormatSerialInt returns the given integer as a string of serial japanese numerals
+// with the sign.
+func (sg *Signs) FormatSerialInt(i int64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = sg.AppendSerialInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// AppendSerialBigInt appends the given big integer as serial japanese numerals with
+// the sign to dst.
+func (sg *Signs) AppendSerialBigInt(dst []byte, i *big.Int) []byte {
+	var abs big.Int
+	abs.Abs(i)
+	return AppendSerialBigInt(sg.appendSign(dst, i.Sign()), &abs)
+}
+
+// FormatSerialBigInt returns the given big integer as a string of serial japanese
+// numerals with the sign.
+func (sg *Signs) FormatSerialBigInt(i *big.Int) string {
+	dst := make([]byte, 0, 2*initialFormatBufferSize)
+	dst = sg.AppendSerialBigInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// AppendHistoricalInt appends the given integer as japanese numerals with the sign
+// to dst and uses 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func (sg *Signs) AppendHistoricalInt(dst []byte, i int64) []byte {
+	dst = sg.appendSign(dst, sign(i))
+	return AppendHistoricalUint(dst, absInt64(i))
+}
+
+// FormatHistoricalInt returns the given integer as a string of japanese numerals
+// with the sign and uses 廿, 卅 and 卌 for the tens 20, 30 and 40.
+func (sg *Signs) FormatHistoricalInt(i int64) string {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst = sg.AppendHistoricalInt(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// Find returns an array of all potential japanese numerals in the given string like
+// the package level Find, but includes a sign in front of a numeral in the result.
+// Value is the absolute value of the numeral and Negative reports the sign. A sign
+// only counts if it does not directly follow the previous numeral, e.g. 二 of 一-二
+// is positive, and if a sign like 負 or マイナス does not continue a word of the
+// same script, e.g. 三 of 勝負三 is positive. Signs like ▲ or - may follow any
+// word, e.g. 売上は▲三百.
+func (sg *Signs) Find(s string) []*SearchResult {
+	results := Find(s)
+	previous := 0
+	for _, result := range results {
+		size, isNegative := sg.cutBefore(s[:result.Start], previous)
+		previous = result.End
+		result.Start -= size
+		result.Str = s[result.Start:result.End]
+		result.Negative = isNegative
+	}
+	return results
+}
+
+// FindBigInt returns an array of all potential japanese numerals in the given string
+// like the package level FindBigInt, but includes a sign in front of a numeral in the
+// result. Value is the absolute value of the numeral and Negative reports the sign
+// like Find.
+func (sg *Signs) FindBigInt(s string) []*SearchBigIntResult {
+	results := FindBigInt(s)
+	previous := 0
+	for _, result := range results {
+		size, isNegative := sg.cutBefore(s[:result.Start], previous)
+		previous = result.End
+		result.Start -= size
+		result.Str = s[result.Start:result.End]
+		result.Negative = isNegative
+	}
+	return results
+}
+
+// sign returns -1, 0 or 1 for the sign of i.
+func sign(i int64) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// absInt64 returns the absolute value of i, which also works for math.MinInt64.
+func absInt64(i int64) uint64 {
+	if i < 0 {
+		return uint64(-i)
+	}
+	return uint64(i)
+}
@@ -0,0 +1,177 @@
+package jnumber
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestSignsParseInt(t *testing.T) {
+	for _, tc := range []struct {
+		Signs    *Signs
+		Input    string
+		Expected int64
+	}{
+		{nil, "マイナス三", -3},
+		{DefaultSigns, "マイナス三", -3},
+		{DefaultSigns, "三", 3},
+		{AccountingSigns, "▲三百", -300},
+		{AccountingSigns, "△三百", -300},
+		{ExtendedSigns, "マイナス 三", -3},
+		{ExtendedSigns, "マイナス　三", -3},
+		{ExtendedSigns, "ﾏｲﾅｽ三", -3},
+		{ExtendedSigns, "負三", -3},
+		{ExtendedSigns, "−三", -3},
+		{ExtendedSigns, "-三", -3},
+		{ExtendedSigns, "▲ 三", -3},
+		{ExtendedSigns, "プラス三", 3},
+		{ExtendedSigns, "ﾌﾟﾗｽ三", 3},
+		{ExtendedSigns, "+三", 3},
+		{ExtendedSigns, "マイナス九百二十二京三千三百七十二兆三百六十八億五千四百七十七万五千八百八", math.MinInt64},
+	} {
+		actual, err := tc.Signs.ParseInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+		actualBigInt, err := tc.Signs.ParseBigInt(tc.Input)
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actualBigInt.Int64())
+	}
+}
+
+func TestSignsParseVariants(t *testing.T) {
+	i, err := ExtendedSigns.ParseSerialInt("▲二〇二三")
+	expectErrNil(t, err)
+	expectEqual(t, int64(-2023), i)
+	i, err = ExtendedSigns.ParseHistoricalInt("負 廿五")
+	expectErrNil(t, err)
+	expectEqual(t, int64(-25), i)
+	b, err := ExtendedSigns.ParseHistoricalBigInt("プラス卅")
+	expectErrNil(t, err)
+	expectEqual(t, "30", b.String())
+	b, err = AccountingSigns.ParseSerialBigInt("▲一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(-1, 20, 0).String(), b.String())
+	expectEqual(t, "▲一〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇〇", AccountingSigns.FormatSerialBigInt(b))
+	b, err = AccountingSigns.ParseBigInt("▲一無量大数")
+	expectErrNil(t, err)
+	expectEqual(t, newTestBigInt(-1, 68, 0).String(), b.String())
+}
+
+func TestSignsParseError(t *testing.T) {
+	for _, tc := range []struct {
+		Signs    *Signs
+		Input    string
+		Offset   int
+		Expected error
+	}{
+		{AccountingSigns, "マイナス三", 0, ErrUnexpectedRune},
+		{DefaultSigns, "マイナス 三", 12, ErrUnexpectedRune},
+		{DefaultSigns, "マイナス", 12, ErrEmpty},
+		{ExtendedSigns, "マイナス マイナス三", 13, ErrUnexpectedRune},
+		{ExtendedSigns, "▲三三", 6, ErrInvalidSequence},
+	} {
+		_, err := tc.Signs.ParseBigInt(tc.Input)
+		expectErrIs(t, tc.Expected, err)
+		var parseErr *ParseError
+		expectEqual(t, true, errors.As(err, &parseErr))
+		expectEqual(t, "Signs.ParseBigInt", parseErr.Func)
+		expectEqual(t, tc.Offset, parseErr.Offset)
+		_, err = tc.Signs.ParseInt(tc.Input)
+		expectErrIs(t, tc.Expected, err)
+	}
+}
+
+func TestSignsFormat(t *testing.T) {
+	showPositive := &Signs{Negative: []string{"−"}, Positive: []string{"＋"}, ShowPositive: true}
+	for _, tc := range []struct {
+		Signs    *Signs
+		Input    int64
+		Expected string
+	}{
+		{nil, -3, "マイナス三"},
+		{AccountingSigns, -300, "▲三百"},
+		{AccountingSigns, 300, "三百"},
+		{AccountingSigns, 0, "零"},
+		{ExtendedSigns, -3, "マイナス三"},
+		{&Signs{}, -3, "マイナス三"},
+		{showPositive, 3, "＋三"},
+		{showPositive, 0, "零"},
+		{showPositive, -3, "−三"},
+		{AccountingSigns, math.MinInt64, "▲九百二十二京三千三百七十二兆三百六十八億五千四百七十七万五千八百八"},
+	} {
+		expectEqual(t, tc.Expected, tc.Signs.FormatInt(tc.Input))
+		expectEqual(t, tc.Expected, string(tc.Signs.AppendInt([]byte{}, tc.Input)))
+		actual, err := tc.Signs.FormatBigInt(big.NewInt(tc.Input))
+		expectErrNil(t, err)
+		expectEqual(t, tc.Expected, actual)
+	}
+	expectEqual(t, "▲二〇二三", AccountingSigns.FormatSerialInt(-2023))
+	expectEqual(t, "▲廿五", AccountingSigns.FormatHistoricalInt(-25))
+	_, err := AccountingSigns.FormatBigInt(newTestBigInt(-1, 72, 0))
+	expectErrIs(t, ErrOverflow, err)
+	dst, err := AccountingSigns.AppendBigInt([]byte("x"), newTestBigInt(-1, 72, 0))
+	expectErrIs(t, ErrOverflow, err)
+	expectEqual(t, "x", string(dst))
+}
+
+func TestSignsFind(t *testing.T) {
+	s := "売上は▲三百万円、利益は 五十万円、損失は△ 二十円"
+	results := AccountingSigns.Find(s)
+	expectEqual(t, 3, len(results))
+	expectEqual(t, "▲三百万", results[0].Str)
+	expectEqual(t, true, results[0].Negative)
+	expectEqual(t, uint64(3_000_000), results[0].Value)
+	expectEqual(t, "五十万", results[1].Str)
+	expectEqual(t, false, results[1].Negative)
+	expectEqual(t, "二十", results[2].Str)
+	expectEqual(t, false, results[2].Negative)
+
+	results = ExtendedSigns.Find(s)
+	expectEqual(t, "△ 二十", results[2].Str)
+	expectEqual(t, true, results[2].Negative)
+	expectEqual(t, s[results[2].Start:results[2].End], results[2].Str)
+
+	bigResults := ExtendedSigns.FindBigInt("マイナス一無量大数と+二")
+	expectEqual(t, 2, len(bigResults))
+	expectEqual(t, "マイナス一無量大数", bigResults[0].Str)
+	expectEqual(t, true, bigResults[0].Negative)
+	expectEqual(t, newTestBigInt(1, 68, 0).String(), bigResults[0].Value.String())
+	expectEqual(t, "+二", bigResults[1].Str)
+	expectEqual(t, false, bigResults[1].Negative)
+	expectEqual(t, "2", bigResults[1].Value.String())
+
+	results = ExtendedSigns.Find("勝負三、一-二、気温はマイナス五、負六")
+	expectEqual(t, 5, len(results))
+	expectEqual(t, "三", results[0].Str)
+	expectEqual(t, false, results[0].Negative)
+	expectEqual(t, "二", results[2].Str)
+	expectEqual(t, false, results[2].Negative)
+	expectEqual(t, "マイナス五", results[3].Str)
+	expectEqual(t, true, results[3].Negative)
+	expectEqual(t, "負六", results[4].Str)
+	expectEqual(t, true, results[4].Negative)
+	bigResults = ExtendedSigns.FindBigInt("一-二")
+	expectEqual(t, "二", bigResults[1].Str)
+	expectEqual(t, false, bigResults[1].Negative)
+}
+
+func TestParserSigns(t *testing.T) {
+	p := Parser{Strict: true, Signs: AccountingSigns}
+	i, err := p.ParseInt("▲三百")
+	expectErrNil(t, err)
+	expectEqual(t, int64(-300), i)
+	_, err = p.ParseInt("△三百")
+	var canonicalErr *CanonicalError
+	expectEqual(t, true, errors.As(err, &canonicalErr))
+	expectEqual(t, "▲三百", canonicalErr.Canonical)
+	_, err = p.ParseBigInt("▲零")
+	expectEqual(t, true, errors.As(err, &canonicalErr))
+	expectEqual(t, RuleNegativeZero, canonicalErr.Rule)
+
+	p = Parser{Lenient: true, Signs: ExtendedSigns}
+	i, rules, err := p.ParseIntRules("マイナス 三百五")
+	expectErrNil(t, err)
+	expectEqual(t, int64(-350), i)
+	expectEqual(t, ImplicitNextUnit, rules)
+}
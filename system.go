@@ -0,0 +1,317 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// NumeralSystem converts between integers and the numerals of one writing system.
+// All implementations in this package are safe for concurrent use.
+type NumeralSystem interface {
+	// ParseUint returns the unsigned integer represented by s.
+	ParseUint(s string) (uint64, error)
+	// ParseBigInt returns the integer represented by s.
+	ParseBigInt(s string) (*big.Int, error)
+	// AppendUint appends the numerals of u to dst.
+	AppendUint(dst []byte, u uint64) []byte
+	// AppendBigInt appends the numerals of i to dst. Returns ErrOverflow if the
+	// system cannot express i.
+	AppendBigInt(dst []byte, i *big.Int) ([]byte, error)
+	// Span returns the number of bytes at the start of s that may belong to a
+	// numeral of the system or 0 if s does not start with a numeral. Used to find
+	// numerals in a text, so it should not include signs.
+	Span(s string) int
+}
+
+var (
+	// Japanese is the NumeralSystem of ParseUint, ParseBigInt, AppendUint and
+	// AppendBigInt.
+	Japanese NumeralSystem = japaneseSystem{}
+	// JapaneseSerial is the NumeralSystem of ParseSerialUint and AppendSerialUint.
+	JapaneseSerial NumeralSystem = japaneseSerialSystem{}
+	// JapaneseHistorical is the NumeralSystem of ParseHistoricalUint and
+	// AppendHistoricalUint.
+	JapaneseHistorical NumeralSystem = japaneseHistoricalSystem{}
+	// ChineseSimplifiedSystem is the NumeralSystem of ParseChineseUint and
+	// AppendChineseUint with ChineseSimplified.
+	ChineseSimplifiedSystem NumeralSystem = chineseSystem{ChineseSimplified}
+	// ChineseTraditionalSystem is the NumeralSystem of ParseChineseUint and
+	// AppendChineseUint with ChineseTraditional.
+	ChineseTraditionalSystem NumeralSystem = chineseSystem{ChineseTraditional}
+	// KoreanHangulSystem is the NumeralSystem of ParseKoreanUint and
+	// AppendKoreanUint with KoreanHangul.
+	KoreanHangulSystem NumeralSystem = koreanSystem{KoreanHangul}
+	// KoreanHanjaSystem is the NumeralSystem of ParseKoreanUint and
+	// AppendKoreanUint with KoreanHanja.
+	KoreanHanjaSystem NumeralSystem = koreanSystem{KoreanHanja}
+)
+
+var (
+	systemsMu sync.RWMutex
+	systems   = map[string]NumeralSystem{
+		"ja":                Japanese,
+		"ja-serial":         JapaneseSerial,
+		"ja-historical":     JapaneseHistorical,
+		"ja-compat":         JapaneseCompatibility,
+		"zh-Hans":           ChineseSimplifiedSystem,
+		"zh-Hant":           ChineseTraditionalSystem,
+		"zh-Hans-financial": chineseSystem{ChineseSimplifiedFinancial},
+		"zh-Hant-financial": chineseSystem{ChineseTraditionalFinancial},
+		"ko":                KoreanHangulSystem,
+		"ko-Hani":           KoreanHanjaSystem,
+	}
+)
+
+// Register makes a NumeralSystem available by the given name. If Register is called
+// twice with the same name or if system is nil, it panics.
+func Register(name string, system NumeralSystem) {
+	systemsMu.Lock()
+	defer systemsMu.Unlock()
+	if system == nil {
+		panic("jnumber: Register system is nil")
+	}
+	if _, dup := systems[name]; dup {
+		panic("jnumber: Register called twice for system " + name)
+	}
+	systems[name] = system
+}
+
+// Lookup returns the NumeralSystem registered with the given name. The systems of
+// this package are registered as "ja", "ja-serial", "ja-historical", "ja-compat",
+// "zh-Hans", "zh-Hant", "zh-Hans-financial", "zh-Hant-financial", "ko" and "ko-Hani".
+func Lookup(name string) (NumeralSystem, bool) {
+	systemsMu.RLock()
+	defer systemsMu.RUnlock()
+	system, ok := systems[name]
+	return system, ok
+}
+
+// FindIn returns an array of all potential numerals of the given system in the given string.
+func FindIn(system NumeralSystem, s string) []*SearchResult {
+	results := make([]*SearchResult, 0)
+	forEachSpan(system, s, func(start, end int) {
+		result := &SearchResult{
+			Start: start,
+			End:   end,
+			Str:   s[start:end],
+		}
+		result.Value, result.Err = system.ParseUint(result.Str)
+		results = append(results, result)
+	})
+	return results
+}
+
+// FindBigIntIn returns an array of all potential numerals of the given system in the
+// given string.
+func FindBigIntIn(system NumeralSystem, s string) []*SearchBigIntResult {
+	results := make([]*SearchBigIntResult, 0)
+	forEachSpan(system, s, func(start, end int) {
+		result := &SearchBigIntResult{
+			Start: start,
+			End:   end,
+			Str:   s[start:end],
+		}
+		result.Value, result.Err = system.ParseBigInt(result.Str)
+		results = append(results, result)
+	})
+	return results
+}
+
+// Convert replaces all numerals of the system from in s with the numerals of the
+// system to. Numerals that cannot be parsed or formatted are kept unchanged.
+func Convert(s string, from, to NumeralSystem) string {
+	var b strings.Builder
+	var dst []byte
+	last := 0
+	forEachSpan(from, s, func(start, end int) {
+		value, err := from.ParseBigInt(s[start:end])
+		if err != nil {
+			return
+		}
+		if dst, err = to.AppendBigInt(dst[:0], value); err != nil {
+			return
+		}
+		b.WriteString(s[last:start])
+		b.Write(dst)
+		last = end
+	})
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// forEachSpan calls fn for all numerals of the system in s.
+func forEachSpan(system NumeralSystem, s string, fn func(start, end int)) {
+	for i := 0; i < len(s); {
+		if n := system.Span(s[i:]); n > 0 {
+			fn(i, i+n)
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+}
+
+// spanFunc returns the number of bytes at the start of s that consist of runes for
+// which fn returns true.
+func spanFunc(s string, fn func(r rune) bool) int {
+	if i := strings.IndexFunc(s, func(r rune) bool { return !fn(r) }); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+type japaneseSystem struct{}
+
+func (japaneseSystem) ParseUint(s string) (uint64, error)     { return ParseUint(s) }
+func (japaneseSystem) ParseBigInt(s string) (*big.Int, error) { return ParseBigInt(s) }
+func (japaneseSystem) AppendUint(dst []byte, u uint64) []byte { return AppendUint(dst, u) }
+func (japaneseSystem) Span(s string) int                      { return japaneseSpan(s, ValueOf) }
+func (japaneseSystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendBigInt(dst, i)
+}
+
+// japaneseSpan returns the number of bytes at the start of s that consist of runes
+// with a value or complete units >= 垓.
+func japaneseSpan(s string, valueOf func(r rune) (uint64, bool)) int {
+	i := 0
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if _, ok := valueOf(r); ok && r != 0 {
+			i += size
+		} else if unitSize := bigUnitSize(s[i:]); unitSize > 0 {
+			i += unitSize
+		} else {
+			break
+		}
+	}
+	return i
+}
+
+type japaneseSerialSystem struct{}
+
+func (japaneseSerialSystem) ParseUint(s string) (uint64, error) { return ParseSerialUint(s) }
+func (japaneseSerialSystem) AppendUint(dst []byte, u uint64) []byte {
+	return AppendSerialUint(dst, u)
+}
+
+func (japaneseSerialSystem) ParseBigInt(s string) (*big.Int, error) {
+	i, err := parseSerialBigInt(s)
+	if err != nil {
+		return nil, newParseError("JapaneseSerial.ParseBigInt", s, err)
+	}
+	return i, nil
+}
+
+func (japaneseSerialSystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendSerialBigInt(dst, i), nil
+}
+
+func (japaneseSerialSystem) Span(s string) int {
+	return spanFunc(s, func(r rune) bool {
+		value, ok := ValueOf(r)
+		return ok && value < 10 && r != 0
+	})
+}
+
+type japaneseHistoricalSystem struct{}
+
+func (japaneseHistoricalSystem) ParseUint(s string) (uint64, error) {
+	return ParseHistoricalUint(s)
+}
+
+func (japaneseHistoricalSystem) ParseBigInt(s string) (*big.Int, error) {
+	return ParseHistoricalBigInt(s)
+}
+
+func (japaneseHistoricalSystem) AppendUint(dst []byte, u uint64) []byte {
+	return AppendHistoricalUint(dst, u)
+}
+
+func (japaneseHistoricalSystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	var buffer [128]byte
+	src, err := AppendBigInt(buffer[:0], i)
+	if err != nil {
+		return dst, err
+	}
+	return appendHistorical(dst, src), nil
+}
+
+func (japaneseHistoricalSystem) Span(s string) int {
+	i := japaneseSpan(s, HistoricalValueOf)
+	// 有 is only a numeral between a unit and the following digits like in 十有五,
+	// but not in 有名 or 千有余
+	for i > 0 && strings.HasPrefix(s[i:], "有") {
+		last, _ := utf8.DecodeLastRuneInString(s[:i])
+		next := japaneseSpan(s[i+len("有"):], HistoricalValueOf)
+		if !isHistoricalUnit(last) || next == 0 {
+			break
+		}
+		i += len("有") + next
+	}
+	return i
+}
+
+type chineseSystem struct {
+	Style ChineseStyle
+}
+
+func (c chineseSystem) ParseUint(s string) (uint64, error)     { return ParseChineseUint(s) }
+func (c chineseSystem) ParseBigInt(s string) (*big.Int, error) { return ParseChineseBigInt(s) }
+func (c chineseSystem) AppendUint(dst []byte, u uint64) []byte {
+	return AppendChineseUint(dst, u, c.Style)
+}
+
+func (c chineseSystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendChineseBigInt(dst, i, c.Style)
+}
+
+func (c chineseSystem) Span(s string) int {
+	return spanFunc(s, func(r rune) bool {
+		_, _, ok := chineseValueOf(r)
+		return ok
+	})
+}
+
+type koreanSystem struct {
+	Style KoreanStyle
+}
+
+func (k koreanSystem) ParseUint(s string) (uint64, error)     { return ParseKoreanUint(s) }
+func (k koreanSystem) ParseBigInt(s string) (*big.Int, error) { return ParseKoreanBigInt(s) }
+func (k koreanSystem) AppendUint(dst []byte, u uint64) []byte {
+	return AppendKoreanUint(dst, u, k.Style)
+}
+
+func (k koreanSystem) AppendBigInt(dst []byte, i *big.Int) ([]byte, error) {
+	return AppendKoreanBigInt(dst, i, k.Style)
+}
+
+func (k koreanSystem) Span(s string) int {
+	i := 0
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if _, unit, ok := koreanValueOf(r); ok {
+			i += size
+			// a single space after a unit >= 만 separates two groups
+			if next, nextSize := utf8.DecodeRuneInString(s[i:]); unit >= 0 && next == ' ' {
+				if _, _, ok := koreanValueOf(firstRune(s[i+nextSize:])); ok {
+					i += nextSize
+				}
+			}
+		} else {
+			break
+		}
+	}
+	return i
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
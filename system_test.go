@@ -0,0 +1,152 @@
+package jnumber
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"ja", "ja-serial", "ja-historical", "ja-compat", "zh-Hans", "zh-Hant", "zh-Hans-financial", "zh-Hant-financial", "ko", "ko-Hani"} {
+		t.Run(name, func(t *testing.T) {
+			system, ok := Lookup(name)
+			expectEqual(t, true, ok)
+			if system == nil {
+				t.Errorf("expected: system, actual: nil")
+			}
+		})
+	}
+	system, ok := Lookup("unknown")
+	expectEqual(t, false, ok)
+	expectEqual(t, nil, system)
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-register", JapaneseSerial)
+	system, ok := Lookup("test-register")
+	expectEqual(t, true, ok)
+	expectEqual(t, JapaneseSerial, system)
+	expectPanic(t, func() { Register("test-register", Japanese) })
+	expectPanic(t, func() { Register("test-register-nil", nil) })
+}
+
+func expectPanic(t *testing.T, fn func()) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected: panic, actual: no panic")
+		}
+	}()
+	fn()
+}
+
+func TestSystemsRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	for name, system := range systems {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 10_000; i++ {
+				expected := random.Uint64() >> random.Intn(64)
+				str := string(system.AppendUint(nil, expected))
+				actual, err := system.ParseUint(str)
+				if err != nil || actual != expected {
+					t.Fatalf("expected: %d, actual: %d, err: %v, str: %s", expected, actual, err, str)
+				}
+				if n := system.Span(str); n != len(str) {
+					t.Fatalf("span expected: %d, actual: %d, str: %s", len(str), n, str)
+				}
+				dst, err := system.AppendBigInt(nil, new(big.Int).SetUint64(expected))
+				if err != nil || string(dst) != str {
+					t.Fatalf("expected: %s, actual: %s, err: %v", str, dst, err)
+				}
+				actualBigInt, err := system.ParseBigInt(str)
+				if err != nil || !actualBigInt.IsUint64() || actualBigInt.Uint64() != expected {
+					t.Fatalf("expected: %d, actual: %s, err: %v, str: %s", expected, actualBigInt, err, str)
+				}
+			}
+		})
+	}
+}
+
+func TestFindIn(t *testing.T) {
+	for _, tc := range []struct {
+		System   NumeralSystem
+		Text     string
+		Expected []*SearchResult
+	}{
+		{Japanese, "一垓と三百", []*SearchResult{
+			{Start: 0, End: 6, Str: "一垓", Err: ErrOverflow},
+			{Start: 9, End: 15, Str: "三百", Value: 300},
+		}},
+		{Japanese, "一恒河と二", []*SearchResult{
+			{Start: 0, End: 3, Str: "一", Value: 1},
+			{Start: 12, End: 15, Str: "二", Value: 2},
+		}},
+		{JapaneseSerial, "二〇二三年一月", []*SearchResult{
+			{Start: 0, End: 12, Str: "二〇二三", Value: 2023},
+			{Start: 15, End: 18, Str: "一", Value: 1},
+		}},
+		{ChineseSimplifiedSystem, "价格一万零五百元", []*SearchResult{
+			{Start: 6, End: 21, Str: "一万零五百", Value: 10_500},
+		}},
+		{JapaneseHistorical, "十有五人と有名な千有余", []*SearchResult{
+			{Start: 0, End: 9, Str: "十有五", Value: 15},
+			{Start: 24, End: 27, Str: "千", Value: 1000},
+		}},
+		{KoreanHangulSystem, "가격: 삼만 오천 원", []*SearchResult{
+			{Start: 8, End: 21, Str: "삼만 오천", Value: 35_000},
+		}},
+	} {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual := FindIn(tc.System, tc.Text)
+			if len(actual) != len(tc.Expected) {
+				t.Fatalf("len expected: %v, actual: %v", len(tc.Expected), len(actual))
+			}
+			for i, expected := range tc.Expected {
+				expectEqual(t, expected.Start, actual[i].Start)
+				expectEqual(t, expected.End, actual[i].End)
+				expectEqual(t, expected.Str, actual[i].Str)
+				expectEqual(t, expected.Value, actual[i].Value)
+				if expected.Err != nil {
+					expectErrIs(t, expected.Err, actual[i].Err)
+				} else {
+					expectErrNil(t, actual[i].Err)
+				}
+			}
+		})
+	}
+}
+
+func TestFindBigIntIn(t *testing.T) {
+	actual := FindBigIntIn(Japanese, "一垓と三百")
+	expectEqual(t, 2, len(actual))
+	expectEqual(t, newTestBigInt(1, 20, 0).String(), actual[0].Value.String())
+	expectEqual(t, "300", actual[1].Value.String())
+}
+
+func TestJapaneseSerialBigInt(t *testing.T) {
+	expected := newTestBigInt(-1, 30, -9)
+	dst, err := JapaneseSerial.AppendBigInt(nil, expected)
+	expectErrNil(t, err)
+	expectEqual(t, negativePrefix+"一"+strings.Repeat("〇", 29)+"九", string(dst))
+	actual, err := JapaneseSerial.ParseBigInt(string(dst))
+	expectErrNil(t, err)
+	expectEqual(t, expected.String(), actual.String())
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		Text     string
+		From, To NumeralSystem
+		Expected string
+	}{
+		{"二〇二三年一月", JapaneseSerial, Japanese, "二千二十三年一月"},
+		{"三万五千円", Japanese, KoreanHangulSystem, "삼만 오천円"},
+		{"一万零五百元", ChineseSimplifiedSystem, ChineseTraditionalSystem, "一萬零五百元"},
+		{"二十三と一一", Japanese, JapaneseHistorical, "廿三と一一"},
+		{"なし", Japanese, JapaneseSerial, "なし"},
+	} {
+		t.Run(tc.Text, func(t *testing.T) {
+			expectEqual(t, tc.Expected, Convert(tc.Text, tc.From, tc.To))
+		})
+	}
+}
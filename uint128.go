@@ -0,0 +1,296 @@
+package jnumber
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// Uint128 is an unsigned 128 bit integer, which supports numbers up to 三百四十澗…
+// (2^128-1) without the allocations of big.Int.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// Int128 is a signed 128 bit integer in two's complement.
+type Int128 struct {
+	Hi int64
+	Lo uint64
+}
+
+// uint128Units contains the values of the units 垓, 秭, 穣, 溝 and 澗.
+var uint128Units = func() (units [5]Uint128) {
+	unit := Uint128{Lo: i京}
+	for k := range units {
+		unit, _ = unit.mul64(i万)
+		units[k] = unit
+	}
+	return units
+}()
+
+// BigInt returns u as a big.Int.
+func (u Uint128) BigInt() *big.Int {
+	i := new(big.Int).SetUint64(u.Hi)
+	i.Lsh(i, 64)
+	return i.Or(i, new(big.Int).SetUint64(u.Lo))
+}
+
+// BigInt returns i as a big.Int.
+func (i Int128) BigInt() *big.Int {
+	abs, isNegative := i.abs()
+	result := abs.BigInt()
+	if isNegative {
+		result.Neg(result)
+	}
+	return result
+}
+
+// abs returns the absolute value of i, which also works for the smallest Int128.
+func (i Int128) abs() (Uint128, bool) {
+	u := Uint128{uint64(i.Hi), i.Lo}
+	if i.Hi < 0 {
+		return u.neg(), true
+	}
+	return u, false
+}
+
+// neg returns the two's complement of u.
+func (u Uint128) neg() Uint128 {
+	lo, borrow := bits.Sub64(0, u.Lo, 0)
+	hi, _ := bits.Sub64(0, u.Hi, borrow)
+	return Uint128{hi, lo}
+}
+
+// mul64 returns u*v and reports whether the result fits into 128 bit.
+func (u Uint128) mul64(v uint64) (Uint128, bool) {
+	overflow, hi := bits.Mul64(u.Hi, v)
+	carry, lo := bits.Mul64(u.Lo, v)
+	hi, carry = bits.Add64(hi, carry, 0)
+	return Uint128{hi, lo}, overflow == 0 && carry == 0
+}
+
+// add returns u+v and reports whether the result fits into 128 bit.
+func (u Uint128) add(v Uint128) (Uint128, bool) {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, carry := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{hi, lo}, carry == 0
+}
+
+// less reports whether u < v.
+func (u Uint128) less(v Uint128) bool {
+	return u.Hi < v.Hi || u.Hi == v.Hi && u.Lo < v.Lo
+}
+
+// uint128FromWords returns the natural number with the given words (see
+// big.Int.Bits) and reports whether it fits into 128 bit.
+func uint128FromWords(words []big.Word) (Uint128, bool) {
+	var u Uint128
+	if len(words)*bits.UintSize > 128 {
+		return u, false
+	}
+	// the shifts by bits.UintSize are split, because go vet rejects shifts by 64
+	for j := len(words) - 1; j >= 0; j-- {
+		u.Hi = u.Hi<<(bits.UintSize-1)<<1 | u.Lo>>(64-bits.UintSize)
+		u.Lo = u.Lo<<(bits.UintSize-1)<<1 | uint64(words[j])
+	}
+	return u, true
+}
+
+// setUint128 sets dst to u and reuses the memory of dst.
+func setUint128(dst *big.Int, u Uint128) *big.Int {
+	return dst.SetBits(appendUint128Words(dst.Bits()[:0], u))
+}
+
+// appendUint128Words appends the little-endian words of u (see big.Int.Bits) to words.
+func appendUint128Words(words []big.Word, u Uint128) []big.Word {
+	for u.Hi > 0 || u.Lo > 0 {
+		words = append(words, big.Word(u.Lo))
+		u.Lo = u.Lo>>(bits.UintSize-1)>>1 | u.Hi<<(64-bits.UintSize)
+		u.Hi = u.Hi >> (bits.UintSize - 1) >> 1
+	}
+	return words
+}
+
+// ParseUint128 returns the unsigned 128 bit integer represented by the given
+// japanese numerals. Supports the units up to 澗 (10^36).
+func ParseUint128(s string) (Uint128, error) {
+	u, err := parseUint128(s, nil)
+	if err != nil {
+		return Uint128{}, newParseError("ParseUint128", s, err)
+	}
+	return u, nil
+}
+
+// ParseInt128 returns the signed 128 bit integer represented by the given japanese
+// numerals. Supports the units up to 澗 (10^36).
+func ParseInt128(s string) (Int128, error) {
+	abs := strings.TrimPrefix(s, negativePrefix)
+	isNegative := s != abs
+	u, err := parseUint128(abs, nil)
+	if err != nil {
+		return Int128{}, newParseError("ParseInt128", s, shiftError(err, len(s)-len(abs)))
+	}
+	if isNegative {
+		if u.Hi > 1<<63 || u.Hi == 1<<63 && u.Lo > 0 {
+			return Int128{}, newParseError("ParseInt128", s, ErrOverflow)
+		}
+		u = u.neg()
+	} else if u.Hi >= 1<<63 {
+		return Int128{}, newParseError("ParseInt128", s, ErrOverflow)
+	}
+	return Int128{int64(u.Hi), u.Lo}, nil
+}
+
+// parseUint128 is parseUint with a 128 bit sum.
+func parseUint128(s string, alphabet *Alphabet) (Uint128, error) {
+	u, offset, err := scanUint128(s, alphabet)
+	if err == ErrUnexpectedRune {
+		err = checkUnexpectedRune(s[offset:])
+	}
+	if err != nil {
+		return Uint128{}, errorAt(offset, err)
+	}
+	return u, nil
+}
+
+// scanUint128 returns the number or the offset and the sentinel error, without the
+// allocation of a ParseError for the fast path of parseBigIntInto.
+func scanUint128(s string, alphabet *Alphabet) (Uint128, int, error) {
+	n := len(s)
+	if n == 0 {
+		return Uint128{}, 0, ErrEmpty
+	}
+	var sum Uint128
+	var seg segment
+	minSegmentEnd := Uint128{math.MaxUint64, math.MaxUint64}
+	i := 0
+	size := utf8KanjiBytes
+loop:
+	for ; i < n-2; i += size {
+		r := decodeUtf8Kanji(i, s)
+		size = utf8KanjiBytes
+		if s[i] >= utf8FourBytesLead {
+			r, size = utf8.DecodeRuneInString(s[i:])
+		}
+		value, ok := alphabet.ValueOf(r)
+		var unit Uint128
+		if value > 0 && ok {
+			if value < i万 {
+				if !seg.push(value) {
+					return Uint128{}, i, ErrInvalidSequence
+				}
+				continue
+			}
+			unit = Uint128{Lo: value}
+		} else if ok {
+			// zero is only valid if it is the only rune
+			if i == 0 {
+				i += size
+				break loop
+			}
+			return Uint128{}, i, ErrInvalidSequence
+		} else {
+			switch r {
+			case '垓':
+				unit = uint128Units[0]
+			case '秭', '𥝱':
+				unit = uint128Units[1]
+			case '穣':
+				unit = uint128Units[2]
+			case '溝':
+				unit = uint128Units[3]
+			case '澗':
+				unit = uint128Units[4]
+			// 10^40 - 10^68 overflows 128 bit
+			// only the first kanji for multi kanji numbers
+			case '正', '載', '極',
+				'恒', '阿', '那', '不',
+				'無':
+				return Uint128{}, i, ErrOverflow
+			default:
+				return Uint128{}, i, ErrUnexpectedRune
+			}
+		}
+		// >= 1_0000: check if we already encountered this number and if there is a
+		// multiplier
+		multiplier := seg.value()
+		if !unit.less(minSegmentEnd) || multiplier == 0 {
+			return Uint128{}, i, ErrInvalidSequence
+		}
+		minSegmentEnd = unit
+		// add the product of the segment and the unit to sum
+		segmentSum, ok := unit.mul64(multiplier)
+		if ok {
+			sum, ok = sum.add(segmentSum)
+		}
+		if !ok {
+			return Uint128{}, i, ErrOverflow
+		}
+		seg = segment{}
+	}
+	// are there still runes in the string after we are done?
+	if i < n {
+		return Uint128{}, i, ErrUnexpectedRune
+	}
+	// add last segment to sum
+	sum, ok := sum.add(Uint128{Lo: seg.value()})
+	if !ok {
+		return Uint128{}, n, ErrOverflow
+	}
+	return sum, n, nil
+}
+
+// AppendUint128 appends the given unsigned 128 bit integer as japanese numerals to
+// dst.
+func AppendUint128(dst []byte, u Uint128) []byte {
+	if u.Hi == 0 {
+		return AppendUint(dst, u.Lo)
+	}
+	return formatUint128(dst, u)
+}
+
+// FormatUint128 returns the given unsigned 128 bit integer as a string of japanese
+// numerals.
+func FormatUint128(u Uint128) string {
+	if u.Hi == 0 {
+		return FormatUint(u.Lo)
+	}
+	dst := make([]byte, 0, 2*initialFormatBufferSize)
+	dst = formatUint128(dst, u)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// AppendInt128 appends the given signed 128 bit integer as japanese numerals to dst.
+func AppendInt128(dst []byte, i Int128) []byte {
+	abs, isNegative := i.abs()
+	if isNegative {
+		dst = append(dst, negativePrefix...)
+	}
+	return AppendUint128(dst, abs)
+}
+
+// FormatInt128 returns the given signed 128 bit integer as a string of japanese
+// numerals.
+func FormatInt128(i Int128) string {
+	dst := make([]byte, 0, 2*initialFormatBufferSize)
+	dst = AppendInt128(dst, i)
+	return unsafe.String(unsafe.SliceData(dst), len(dst))
+}
+
+// formatUint128 appends u > 0 to dst. u is split into high·10^32 + middle·10^16 +
+// low with two 128 bit divisions by 10^16.
+func formatUint128(dst []byte, u Uint128) []byte {
+	quotientHi, remainder := u.Hi/i京, u.Hi%i京
+	quotientLo, low := bits.Div64(remainder, u.Lo, i京)
+	high, middle := bits.Div64(quotientHi, quotientLo, i京)
+	var groups [10]uint64
+	for k := 0; k < 4; k++ {
+		groups[k], groups[k+4] = low%i万, middle%i万
+		low, middle = low/i万, middle/i万
+	}
+	groups[8], groups[9] = high%i万, high/i万
+	return appendGroups(dst, groups[:])
+}
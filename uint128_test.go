@@ -0,0 +1,118 @@
+package jnumber
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+var uint128Cases = []struct {
+	String string
+	Value  Uint128
+}{
+	{"零", Uint128{}},
+	{"一", Uint128{0, 1}},
+	{"千八百四十四京六千七百四十四兆七百三十七億九百五十五万千六百十五", Uint128{0, math.MaxUint64}},
+	{"千八百四十四京六千七百四十四兆七百三十七億九百五十五万千六百十六", Uint128{1, 0}},
+	{"一垓", Uint128{5, 7766279631452241920}},
+	{"一澗", Uint128{54210108624275221, 12919594847110692864}},
+	{"三百四十澗二千八百二十三溝六千六百九十二穣九百三十八秭四千六百三十四垓六千三百三十七京四千六百七兆四千三百十七億六千八百二十一万千四百五十五", Uint128{math.MaxUint64, math.MaxUint64}},
+}
+
+func TestParseUint128(t *testing.T) {
+	for _, tc := range uint128Cases {
+		t.Run(tc.String, func(t *testing.T) {
+			actual, err := ParseUint128(tc.String)
+			expectErrNil(t, err)
+			expectEqual(t, tc.Value, actual)
+		})
+	}
+}
+
+func TestFormatUint128(t *testing.T) {
+	for _, tc := range uint128Cases {
+		t.Run(tc.String, func(t *testing.T) {
+			expectEqual(t, tc.String, FormatUint128(tc.Value))
+			expectEqual(t, "prefix "+tc.String, string(AppendUint128([]byte("prefix "), tc.Value)))
+			expectEqual(t, tc.String, FormatBigInt(tc.Value.BigInt()))
+		})
+	}
+}
+
+func TestParseUint128Error(t *testing.T) {
+	testParseError(t, commonErrorCases, ParseUint128)
+	testParseError(t, []parseErrorTestCase{
+		{"三百四十澗二千八百二十三溝六千六百九十二穣九百三十八秭四千六百三十四垓六千三百三十七京四千六百七兆四千三百十七億六千八百二十一万千四百五十六", ErrOverflow},
+		{"三百五十澗", ErrOverflow},
+		{"一正", ErrOverflow},
+		{"一澗一澗", ErrInvalidSequence},
+		{"一垓一澗", ErrInvalidSequence},
+		{"\xe0\x80\x80", ErrEncoding},
+		{"一垓\xe0\x80\x80", ErrEncoding},
+	}, ParseUint128)
+}
+
+func TestParseInt128(t *testing.T) {
+	minInt128 := Int128{math.MinInt64, 0}
+	maxInt128 := Int128{math.MaxInt64, math.MaxUint64}
+	for _, i := range []Int128{{}, {-1, math.MaxUint64}, {0, 1}, {-1, 0}, minInt128, maxInt128} {
+		str := FormatInt128(i)
+		expectEqual(t, FormatBigInt(i.BigInt()), str)
+		actual, err := ParseInt128(str)
+		expectErrNil(t, err)
+		expectEqual(t, i, actual)
+	}
+	_, err := ParseInt128(FormatUint128(Uint128{1 << 63, 0}))
+	expectErrIs(t, ErrOverflow, err)
+	_, err = ParseInt128(negativePrefix + FormatUint128(Uint128{1 << 63, 1}))
+	expectErrIs(t, ErrOverflow, err)
+	_, err = ParseInt128(negativePrefix + "一一")
+	expectErrIs(t, ErrInvalidSequence, err)
+}
+
+func TestFormatParseUint128Random(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100_000; i++ {
+		expected := Uint128{random.Uint64() >> (i % 64), random.Uint64()}
+		str := FormatUint128(expected)
+		actual, err := ParseUint128(str)
+		if err != nil || actual != expected {
+			t.Fatalf("expected: %v, actual: %v, err: %v, str: %s", expected, actual, err, str)
+		}
+		bigActual, err := ParseBigInt(str)
+		if err != nil || bigActual.Cmp(expected.BigInt()) != 0 {
+			t.Fatalf("expected: %v, actual: %s, err: %v, str: %s", expected.BigInt(), bigActual, err, str)
+		}
+	}
+}
+
+func TestSetUint128(t *testing.T) {
+	var dst big.Int
+	for _, tc := range uint128Cases {
+		expectEqual(t, tc.Value.BigInt().String(), setUint128(&dst, tc.Value).String())
+		u, ok := uint128FromWords(dst.Bits())
+		expectEqual(t, true, ok)
+		expectEqual(t, tc.Value, u)
+	}
+}
+
+func BenchmarkParseUint128(b *testing.B) {
+	for _, tc := range uint128Cases {
+		b.Run(tc.String, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParseUint128(tc.String)
+			}
+		})
+	}
+}
+
+func BenchmarkFormatUint128(b *testing.B) {
+	for _, tc := range uint128Cases {
+		b.Run(tc.String, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FormatUint128(tc.Value)
+			}
+		})
+	}
+}
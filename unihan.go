@@ -0,0 +1,107 @@
+package jnumber
+
+import (
+	_ "embed"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NumericCategory is the Unihan field that defines the numeric value of a rune.
+type NumericCategory int
+
+const (
+	// PrimaryNumeric is the category of the regular numerals (kPrimaryNumeric): 一, 十, 萬
+	PrimaryNumeric NumericCategory = iota
+	// AccountingNumeric is the category of the financial numerals (kAccountingNumeric): 壹, 拾, 仟
+	AccountingNumeric
+	// OtherNumeric is the category of rare or regional numerals (kOtherNumeric): 兩, 廾, 卄
+	OtherNumeric
+)
+
+// String returns the name of the Unihan field.
+func (c NumericCategory) String() string {
+	switch c {
+	case PrimaryNumeric:
+		return "kPrimaryNumeric"
+	case AccountingNumeric:
+		return "kAccountingNumeric"
+	case OtherNumeric:
+		return "kOtherNumeric"
+	default:
+		return "NumericCategory(" + strconv.Itoa(int(c)) + ")"
+	}
+}
+
+// unihanNumericValues is derived from Unihan_NumericValues.txt.
+//
+//go:embed unihan_numeric.txt
+var unihanNumericValues string
+
+type unihanValue struct {
+	Value    uint64
+	Category NumericCategory
+}
+
+var (
+	unihanValues     map[rune]unihanValue
+	unihanValuesOnce sync.Once
+)
+
+func initUnihanValues() {
+	unihanValues = make(map[rune]unihanValue)
+	for _, line := range strings.Split(unihanNumericValues, "\n") {
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		codePoint, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "U+"), 16, 32)
+		if err != nil {
+			panic("jnumber: invalid code point in unihan_numeric.txt: " + line)
+		}
+		value, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			panic("jnumber: invalid value in unihan_numeric.txt: " + line)
+		}
+		var category NumericCategory
+		switch fields[1] {
+		case "kPrimaryNumeric":
+			category = PrimaryNumeric
+		case "kAccountingNumeric":
+			category = AccountingNumeric
+		default:
+			category = OtherNumeric
+		}
+		unihanValues[rune(codePoint)] = unihanValue{value, category}
+	}
+}
+
+// UnihanValueOf returns the numeric value and category of a single Han character as
+// defined by the Unihan database, e.g. 2 for 兩, 20 for 卄 or 100 for 陌. Covers far
+// more runes than ValueOf, but is slower and not used by the parse functions. Runes
+// that are not Han characters like 〇 are not part of Unihan.
+func UnihanValueOf(r rune) (value uint64, category NumericCategory, ok bool) {
+	unihanValuesOnce.Do(initUnihanValues)
+	val, ok := unihanValues[r]
+	return val.Value, val.Category, ok
+}
+
+// BigUnitValueOf returns the value of a unit >= 万 in the default unit system, which
+// includes the multi kanji units like 恒河沙 (10^52) or 無量大数 (10^68). The result
+// is a new big.Int that may be modified by the caller.
+func BigUnitValueOf(s string) (*big.Int, bool) {
+	initBigIntsOnce.Do(initBigInts)
+	for _, unit := range myriadUnits {
+		if unit.Kanji == s {
+			return new(big.Int).Set(unit.Value), true
+		}
+	}
+	switch s {
+	case "萬":
+		return new(big.Int).Set(&b万), true
+	case "穰":
+		return new(big.Int).Set(&b穣), true
+	}
+	return nil, false
+}
@@ -0,0 +1,81 @@
+package jnumber
+
+import (
+	"testing"
+)
+
+func TestUnihanValueOf(t *testing.T) {
+	for _, tc := range []struct {
+		Rune     rune
+		Value    uint64
+		Category NumericCategory
+	}{
+		{'一', 1, PrimaryNumeric},
+		{'零', 0, PrimaryNumeric},
+		{'亿', i億, PrimaryNumeric},
+		{'兆', i兆, PrimaryNumeric},
+		{'壹', 1, AccountingNumeric},
+		{'仟', 1000, AccountingNumeric},
+		{'兩', 2, OtherNumeric},
+		{'廾', 9, OtherNumeric},
+		{'卄', 20, OtherNumeric},
+		{'陌', 100, OtherNumeric},
+		{'弌', 1, OtherNumeric},
+		{'\U00020001', 7, OtherNumeric},
+		{'零', 0, PrimaryNumeric},
+	} {
+		value, category, ok := UnihanValueOf(tc.Rune)
+		expectEqual(t, true, ok)
+		expectEqual(t, tc.Value, value)
+		expectEqual(t, tc.Category, category)
+	}
+	for _, r := range []rune{0, 'a', '〇', '垓', '数'} {
+		_, _, ok := UnihanValueOf(r)
+		expectEqual(t, false, ok)
+	}
+}
+
+func TestUnihanValueOfMatchesValueOf(t *testing.T) {
+	for _, r := range uint64Kanjis {
+		expected, _ := ValueOf(r)
+		if actual, _, ok := UnihanValueOf(r); ok {
+			expectEqual(t, expected, actual)
+		}
+	}
+}
+
+func TestNumericCategoryString(t *testing.T) {
+	expectEqual(t, "kPrimaryNumeric", PrimaryNumeric.String())
+	expectEqual(t, "kAccountingNumeric", AccountingNumeric.String())
+	expectEqual(t, "kOtherNumeric", OtherNumeric.String())
+	expectEqual(t, "NumericCategory(3)", NumericCategory(3).String())
+}
+
+func TestBigUnitValueOf(t *testing.T) {
+	for _, tc := range []struct {
+		String   string
+		Exponent int64
+	}{
+		{"万", 4},
+		{"萬", 4},
+		{"京", 16},
+		{"垓", 20},
+		{"穰", 28},
+		{"恒河沙", 52},
+		{"不可思議", 64},
+		{"無量大数", 68},
+	} {
+		actual, ok := BigUnitValueOf(tc.String)
+		expectEqual(t, true, ok)
+		expectEqual(t, newTestBigInt(1, tc.Exponent, 0).String(), actual.String())
+	}
+	for _, s := range []string{"", "十", "恒河", "無量大数無量大数"} {
+		_, ok := BigUnitValueOf(s)
+		expectEqual(t, false, ok)
+	}
+	// the result must be a copy
+	actual, _ := BigUnitValueOf("万")
+	actual.SetUint64(1)
+	actual, _ = BigUnitValueOf("万")
+	expectEqual(t, "10000", actual.String())
+}
@@ -0,0 +1,177 @@
+package jnumber
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// UnitSystem defines the values of the units starting with 万. The units below
+// 万 (十, 百 and 千) are the same in all systems.
+//
+// The units of the Buddhist Avatamsaka Sutra (華厳経) that go beyond 無量大数, like
+// 不可説不可説転 (10^(7×2^122)), are far too big to be represented by big.Int and are
+// therefore not supported.
+type UnitSystem int
+
+const (
+	// Myriad is the 万進 system, where every unit is 10^4 times the previous one:
+	// 億 = 10^8, 兆 = 10^12, 垓 = 10^20 … 無量大数 = 10^68. This is the system used
+	// in modern Japanese and the one used by all functions without a UnitSystem
+	// parameter.
+	Myriad UnitSystem = iota
+	// DoubleMyriad is the 万万進 system (中数), where 億 = 10^8 and every following
+	// unit is 10^8 times the previous one: 兆 = 10^16, 垓 = 10^32 … 無量大数 = 10^128.
+	DoubleMyriad
+	// Decimal is the 下数 system, where every unit after 万 is 10 times the previous
+	// one: 億 = 10^5, 兆 = 10^6, 垓 = 10^8 … 無量大数 = 10^20.
+	Decimal
+	numberOfUnitSystems
+)
+
+// exponent returns the power of ten of the k-th unit >= 万.
+func (system UnitSystem) exponent(k int) int {
+	switch system {
+	case DoubleMyriad:
+		if k == 0 {
+			return 4
+		}
+		return 8 * k
+	case Decimal:
+		return 4 + k
+	default:
+		return 4 * (k + 1)
+	}
+}
+
+// multiplierDigits returns the maximum number of digits of the multiplier of the
+// k-th unit >= 万.
+func (system UnitSystem) multiplierDigits(k int) int {
+	if k+1 < len(myriadUnits) {
+		return system.exponent(k+1) - system.exponent(k)
+	}
+	// the multiplier of the biggest unit is limited to the digits of a single
+	// group, e.g. 9999 for Myriad, 99999999 for DoubleMyriad and 9 for Decimal,
+	// so that parsing and formatting accept the same range
+	switch system {
+	case DoubleMyriad:
+		return 8
+	case Decimal:
+		return 1
+	default:
+		return 4
+	}
+}
+
+var (
+	unitValues     [numberOfUnitSystems][len(myriadUnits)]big.Int
+	unitValuesOnce [numberOfUnitSystems]sync.Once
+)
+
+// values returns the values of all units >= 万 in ascending order.
+func (system UnitSystem) values() *[len(myriadUnits)]big.Int {
+	values := &unitValues[system]
+	unitValuesOnce[system].Do(func() {
+		var ten big.Int
+		ten.SetUint64(10)
+		for k := range values {
+			values[k].Exp(&ten, big.NewInt(int64(system.exponent(k))), nil)
+		}
+	})
+	return values
+}
+
+// ParseBigIntUnits returns the integer represented by the given japanese numerals,
+// where the units starting with 万 have the values defined by the given system.
+// Accepts the same range as FormatBigIntUnits and returns ErrOverflow for bigger
+// numbers, e.g. 一無量大数無量大数.
+func ParseBigIntUnits(s string, system UnitSystem) (*big.Int, error) {
+	i, err := parseBigIntUnits(s, system)
+	if err != nil {
+		return nil, newParseError("ParseBigIntUnits", s, err)
+	}
+	return i, nil
+}
+
+func parseBigIntUnits(s string, system UnitSystem) (*big.Int, error) {
+	if s == "" {
+		return nil, ErrEmpty
+	}
+	initBigIntsOnce.Do(initBigInts)
+	abs := strings.TrimPrefix(s, negativePrefix)
+	rules := numeralRules{System: system}
+	parser := newBigIntParser(new(big.Int), &rules, nil)
+	if err := parser.parse(abs); err != nil {
+		return nil, shiftError(err, len(s)-len(abs))
+	}
+	result := parser.bigInt()
+	if s != abs {
+		result.Neg(result)
+	}
+	return result, nil
+}
+
+// FormatBigIntUnits returns the given big integer as a string of Japanese numerals,
+// where the units starting with 万 have the values defined by the given system.
+// Returns ErrOverflow if the number is too big for the biggest unit of the system.
+func FormatBigIntUnits(i *big.Int, system UnitSystem) (string, error) {
+	dst := make([]byte, 0, initialFormatBufferSize)
+	dst, err := AppendBigIntUnits(dst, i, system)
+	if err != nil {
+		return "", err
+	}
+	return unsafe.String(unsafe.SliceData(dst), len(dst)), nil
+}
+
+// AppendBigIntUnits appends the given big integer as Japanese numerals to dst, where
+// the units starting with 万 have the values defined by the given system. Returns
+// ErrOverflow if the number is too big for the biggest unit of the system.
+func AppendBigIntUnits(dst []byte, i *big.Int, system UnitSystem) ([]byte, error) {
+	if i.IsInt64() {
+		if abs := i.Int64(); -i万 < abs && abs < i万 {
+			return AppendInt(dst, abs), nil
+		}
+	}
+	var u big.Int
+	u.Abs(i)
+	if i.Sign() < 0 {
+		dst = append(dst, negativePrefix...)
+	}
+	return appendBigIntUnits(dst, &u, system)
+}
+
+// appendBigIntUnits appends the positive number u >= 万. Modifies u.
+func appendBigIntUnits(dst []byte, u *big.Int, system UnitSystem) ([]byte, error) {
+	values := system.values()
+	k := len(values) - 1
+	for u.Cmp(&values[k]) < 0 {
+		k--
+	}
+	var multiplier big.Int
+	multiplier.DivMod(u, &values[k], u)
+	if len(multiplier.Text(10)) > system.multiplierDigits(k) {
+		return dst, ErrOverflow
+	}
+	var err error
+	if dst, err = appendBigIntUnitsPart(dst, &multiplier, system); err != nil {
+		return dst, err
+	}
+	dst = append(dst, myriadUnits[k].Kanji...)
+	if u.Sign() > 0 {
+		return appendBigIntUnitsPart(dst, u, system)
+	}
+	return dst, nil
+}
+
+// appendBigIntUnitsPart appends the positive number u, which may be smaller than 万.
+func appendBigIntUnitsPart(dst []byte, u *big.Int, system UnitSystem) ([]byte, error) {
+	if u.IsUint64() && u.Uint64() < i万 {
+		if u.Uint64() == 1 {
+			// the formatter omits 一 in front of 十, 百 and 千, but not in front of 万
+			return append(dst, "一"...), nil
+		}
+		return formatUnsigned(dst, u.Uint64()), nil
+	}
+	return appendBigIntUnits(dst, u, system)
+}
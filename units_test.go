@@ -0,0 +1,155 @@
+package jnumber
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+type unitSystemTestCase struct {
+	Text     string
+	System   UnitSystem
+	Expected *big.Int
+}
+
+var unitSystemTestCases = []unitSystemTestCase{
+	{"一万", DoubleMyriad, newTestBigInt(1, 4, 0)},
+	{"一億", DoubleMyriad, newTestBigInt(1, 8, 0)},
+	{"一万億", DoubleMyriad, newTestBigInt(1, 12, 0)},
+	{"一兆", DoubleMyriad, newTestBigInt(1, 16, 0)},
+	{"一万一億", DoubleMyriad, newTestBigInt(10_001, 8, 0)},
+	{"九千九百九十九万九千九百九十九億", DoubleMyriad, newTestBigInt(99_999_999, 8, 0)},
+	{"二兆三千万億四万五", DoubleMyriad, newTestBigInt(2, 16, 3000*i万*i億+40_005)},
+	{"一垓", DoubleMyriad, newTestBigInt(1, 32, 0)},
+	{"一無量大数", DoubleMyriad, newTestBigInt(1, 128, 0)},
+	{"一万", Decimal, newTestBigInt(1, 4, 0)},
+	{"一億", Decimal, newTestBigInt(1, 5, 0)},
+	{"三億五万", Decimal, newTestBigInt(350_000, 0, 0)},
+	{"九億九万九千九百九十九", Decimal, newTestBigInt(999_999, 0, 0)},
+	{"一兆", Decimal, newTestBigInt(1, 6, 0)},
+	{"一無量大数", Decimal, newTestBigInt(1, 20, 0)},
+	{"九無量大数九不可思議", Decimal, newTestBigInt(99, 19, 0)},
+	{negativePrefix + "一兆一", Decimal, newTestBigInt(-1, 6, -1)},
+}
+
+var unitSystemErrorCases = []struct {
+	Text     string
+	System   UnitSystem
+	Expected error
+}{
+	{"一億一兆", DoubleMyriad, ErrInvalidSequence},
+	{"一万万億", DoubleMyriad, ErrInvalidSequence},
+	{"一億二億", DoubleMyriad, ErrInvalidSequence},
+	{"一万億一億", DoubleMyriad, ErrInvalidSequence},
+	{"億", DoubleMyriad, ErrInvalidSequence},
+	{"十万", Decimal, ErrInvalidSequence},
+	{"一万一億", Decimal, ErrInvalidSequence},
+	{"一恒河", Decimal, ErrEOF},
+	{"一恒河一", Decimal, &UnexpectedRuneError{'一', '沙'}},
+	{"一無量大数無量大数", Myriad, ErrOverflow},
+	{"一万無量大数", Myriad, ErrOverflow},
+	{"一無量大数無量大数", DoubleMyriad, ErrOverflow},
+	{"一億無量大数", DoubleMyriad, ErrOverflow},
+	{"一無量大数無量大数", Decimal, ErrOverflow},
+	{"十無量大数", Decimal, ErrOverflow},
+}
+
+func TestParseBigIntUnits(t *testing.T) {
+	aliasTestCases := []unitSystemTestCase{
+		{"一𥝱", Decimal, newTestBigInt(1, 9, 0)},
+		{"一𥝱", DoubleMyriad, newTestBigInt(1, 40, 0)},
+	}
+	for _, tc := range append(unitSystemTestCases, aliasTestCases...) {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := ParseBigIntUnits(tc.Text, tc.System)
+			expectErrNil(t, err)
+			if actual == nil || tc.Expected.Cmp(actual) != 0 {
+				t.Errorf("expected: %s, actual: %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseBigIntUnitsError(t *testing.T) {
+	for _, system := range []UnitSystem{DoubleMyriad, Decimal} {
+		testParseError(t, commonErrorCases, func(s string) (*big.Int, error) {
+			return ParseBigIntUnits(s, system)
+		})
+	}
+	for _, tc := range unitSystemErrorCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := ParseBigIntUnits(tc.Text, tc.System)
+			if actual != nil {
+				t.Errorf("expected: nil, actual: %s", actual)
+			}
+			expectErrIs(t, tc.Expected, err)
+		})
+	}
+}
+
+func TestFormatBigIntUnits(t *testing.T) {
+	for _, tc := range unitSystemTestCases {
+		t.Run(tc.Text, func(t *testing.T) {
+			actual, err := FormatBigIntUnits(tc.Expected, tc.System)
+			expectErrNil(t, err)
+			expectEqual(t, tc.Text, actual)
+		})
+	}
+}
+
+func TestFormatBigIntUnitsMyriad(t *testing.T) {
+	for _, tc := range formatBigIntCases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			actual, err := FormatBigIntUnits(tc.Number, Myriad)
+			expectErrNil(t, err)
+			expectEqual(t, tc.Expected, actual)
+		})
+	}
+	for _, tc := range commonTestCases {
+		t.Run(tc.String, func(t *testing.T) {
+			actual, err := FormatBigIntUnits(big.NewInt(tc.Value), Myriad)
+			expectErrNil(t, err)
+			expectEqual(t, tc.String, actual)
+		})
+	}
+}
+
+func TestFormatBigIntUnitsOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		System UnitSystem
+		Number *big.Int
+	}{
+		{Myriad, newTestBigInt(1, 72, 0)},
+		{DoubleMyriad, newTestBigInt(1, 136, 0)},
+		{Decimal, newTestBigInt(1, 21, 0)},
+		{Decimal, newTestBigInt(-1, 21, 0)},
+	} {
+		actual, err := FormatBigIntUnits(tc.Number, tc.System)
+		expectEqual(t, "", actual)
+		expectErrIs(t, ErrOverflow, err)
+	}
+}
+
+func TestFormatParseBigIntUnitsRandom(t *testing.T) {
+	for _, system := range []UnitSystem{Myriad, DoubleMyriad, Decimal} {
+		digits := system.exponent(len(myriadUnits)-1) + system.multiplierDigits(len(myriadUnits)-1)
+		var limit big.Int
+		limit.Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+		random := rand.New(rand.NewSource(int64(system)))
+		for i := 0; i < 10_000; i++ {
+			var expected big.Int
+			expected.Rand(random, &limit)
+			str, err := FormatBigIntUnits(&expected, system)
+			if err != nil {
+				t.Fatalf("err: %v, expected: %s", err, &expected)
+			}
+			actual, err := ParseBigIntUnits(str, system)
+			if err != nil {
+				t.Fatalf("err: %v, str: %s", err, str)
+			}
+			if actual.Cmp(&expected) != 0 {
+				t.Fatalf("expected: %s, actual: %s, str: %s", &expected, actual, str)
+			}
+		}
+	}
+}